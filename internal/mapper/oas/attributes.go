@@ -0,0 +1,442 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oas
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-codegen-spec/datasource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/resource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/schema"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// BuildResourceAttributes dispatches every property of an object schema to
+// the resource attribute builder matching its OAS shape - object, array, or
+// primitive - using DetermineNestingMode to pick between the SingleNested,
+// Map/MapNested, and primitive-element List/Set builders,
+// HasAdditionalPropertiesHybrid and HasPolymorphism to route to their
+// respective builders first, and falling back to a primitive attribute
+// otherwise. registry is threaded into every leaf the recursion reaches -
+// including the keys of a MapNestedAttribute's NestedObject - except the
+// attributes of oneOf/anyOf variants, which BuildDynamicNestedResource builds
+// with a nil registry, so they get only the built-in constraint translation.
+// registry may be nil, in which case every leaf falls back to the
+// generator's built-in constraint translation.
+func (s *OASSchema) BuildResourceAttributes(registry *ConstraintRegistry) (*[]resource.Attribute, error) {
+	attributes := make([]resource.Attribute, 0, len(s.Schema.Properties))
+
+	for _, name := range sortedPropertyNames(s.Schema.Properties) {
+		propSchema := &OASSchema{Schema: s.Schema.Properties[name].Schema()}
+
+		attribute, err := propSchema.buildResourceAttribute(name, computedOptionalRequiredFor(name, s.Schema.Required), registry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build resource attribute %q - %w", name, err)
+		}
+
+		attributes = append(attributes, *attribute)
+	}
+
+	return &attributes, nil
+}
+
+// BuildDataSourceAttributes mirrors BuildResourceAttributes for data sources.
+func (s *OASSchema) BuildDataSourceAttributes(registry *ConstraintRegistry) (*[]datasource.Attribute, error) {
+	attributes := make([]datasource.Attribute, 0, len(s.Schema.Properties))
+
+	for _, name := range sortedPropertyNames(s.Schema.Properties) {
+		propSchema := &OASSchema{Schema: s.Schema.Properties[name].Schema()}
+
+		attribute, err := propSchema.buildDataSourceAttribute(name, computedOptionalRequiredFor(name, s.Schema.Required), registry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build data source attribute %q - %w", name, err)
+		}
+
+		attributes = append(attributes, *attribute)
+	}
+
+	return &attributes, nil
+}
+
+// sortedPropertyNames returns an object schema's property names in
+// alphabetical order, so generated attributes appear in a stable order
+// regardless of the OAS document's own property ordering.
+func sortedPropertyNames(properties map[string]*base.SchemaProxy) []string {
+	names := make([]string, 0, len(properties))
+	for name := range properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// computedOptionalRequiredFor returns schema.Required when name is listed in
+// required, and schema.ComputedOptional otherwise.
+func computedOptionalRequiredFor(name string, required []string) schema.ComputedOptionalRequired {
+	for _, requiredName := range required {
+		if requiredName == name {
+			return schema.Required
+		}
+	}
+
+	return schema.ComputedOptional
+}
+
+func (s *OASSchema) isObjectType() bool {
+	return s.Schema != nil && len(s.Schema.Type) > 0 && s.Schema.Type[0] == "object"
+}
+
+func (s *OASSchema) isArrayType() bool {
+	return s.Schema != nil && len(s.Schema.Type) > 0 && s.Schema.Type[0] == "array"
+}
+
+// buildResourceAttribute dispatches a single property schema to the builder
+// matching its shape.
+func (s *OASSchema) buildResourceAttribute(name string, computability schema.ComputedOptionalRequired, registry *ConstraintRegistry) (*resource.Attribute, error) {
+	switch {
+	case s.HasPolymorphism():
+		return s.BuildDynamicNestedResource(name, computability)
+	case s.isObjectType() && !s.HasAdditionalPropertiesHybrid() && DetermineNestingMode(s) == NestingModeMap:
+		return s.buildMapResource(name, computability, registry)
+	case s.isObjectType():
+		return s.BuildSingleNestedResource(name, computability, registry)
+	case s.isArrayType():
+		return s.buildCollectionResource(name, computability, registry)
+	default:
+		return s.buildPrimitiveResource(name, computability, registry)
+	}
+}
+
+// buildDataSourceAttribute mirrors buildResourceAttribute for data sources.
+func (s *OASSchema) buildDataSourceAttribute(name string, computability schema.ComputedOptionalRequired, registry *ConstraintRegistry) (*datasource.Attribute, error) {
+	switch {
+	case s.HasPolymorphism():
+		return s.BuildDynamicNestedDataSource(name, computability)
+	case s.isObjectType() && !s.HasAdditionalPropertiesHybrid() && DetermineNestingMode(s) == NestingModeMap:
+		return s.buildMapDataSource(name, computability, registry)
+	case s.isObjectType():
+		return s.BuildSingleNestedDataSource(name, computability, registry)
+	case s.isArrayType():
+		return s.buildCollectionDataSource(name, computability, registry)
+	default:
+		return s.buildPrimitiveDataSource(name, computability, registry)
+	}
+}
+
+// buildMapResource builds a Map or MapNested resource.Attribute from an
+// object schema's `additionalProperties`, depending on whether the resolved
+// value schema is itself an object. registry is passed down to the nested
+// object's own attributes, so a MapNestedAttribute's NestedObject.Attributes
+// get the same constraint treatment as any other leaf.
+func (s *OASSchema) buildMapResource(name string, computability schema.ComputedOptionalRequired, registry *ConstraintRegistry) (*resource.Attribute, error) {
+	valueSchema := &OASSchema{Schema: s.Schema.AdditionalProperties.Schema()}
+
+	if valueSchema.isObjectType() {
+		nestedAttributes, err := valueSchema.BuildResourceAttributes(registry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build map nested object schema - %w", err)
+		}
+
+		return &resource.Attribute{
+			Name: name,
+			MapNested: &resource.MapNestedAttribute{
+				NestedObject:             resource.NestedAttributeObject{Attributes: *nestedAttributes},
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+			},
+		}, nil
+	}
+
+	elementType, err := valueSchema.buildElementType()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build map element type - %w", err)
+	}
+
+	return &resource.Attribute{
+		Name: name,
+		Map: &resource.MapAttribute{
+			ElementType:              elementType,
+			ComputedOptionalRequired: computability,
+			Description:              s.GetDescription(),
+		},
+	}, nil
+}
+
+func (s *OASSchema) buildMapDataSource(name string, computability schema.ComputedOptionalRequired, registry *ConstraintRegistry) (*datasource.Attribute, error) {
+	valueSchema := &OASSchema{Schema: s.Schema.AdditionalProperties.Schema()}
+
+	if valueSchema.isObjectType() {
+		nestedAttributes, err := valueSchema.BuildDataSourceAttributes(registry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build map nested object schema - %w", err)
+		}
+
+		return &datasource.Attribute{
+			Name: name,
+			MapNested: &datasource.MapNestedAttribute{
+				NestedObject:             datasource.NestedAttributeObject{Attributes: *nestedAttributes},
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+			},
+		}, nil
+	}
+
+	elementType, err := valueSchema.buildElementType()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build map element type - %w", err)
+	}
+
+	return &datasource.Attribute{
+		Name: name,
+		Map: &datasource.MapAttribute{
+			ElementType:              elementType,
+			ComputedOptionalRequired: computability,
+			Description:              s.GetDescription(),
+		},
+	}, nil
+}
+
+// buildCollectionResource builds a List or Set resource.Attribute from an
+// array schema, using DetermineNestingMode to decide between them. Arrays of
+// objects aren't supported yet; collections are limited to primitive
+// elements for now. registry is honored for a custom format override on the
+// array schema itself; minItems/maxItems/uniqueItems fall back to the
+// generator's built-in validators otherwise.
+func (s *OASSchema) buildCollectionResource(name string, computability schema.ComputedOptionalRequired, registry *ConstraintRegistry) (*resource.Attribute, error) {
+	itemSchema := &OASSchema{Schema: s.Schema.Items.A.Schema()}
+	if itemSchema.isObjectType() {
+		return nil, fmt.Errorf("arrays of objects are not yet supported")
+	}
+
+	elementType, err := itemSchema.buildElementType()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build array element type - %w", err)
+	}
+
+	if DetermineNestingMode(s) == NestingModeSet {
+		return &resource.Attribute{
+			Name: name,
+			Set: &resource.SetAttribute{
+				ElementType:              elementType,
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+				Validators:               s.buildSetValidators(registry),
+			},
+		}, nil
+	}
+
+	return &resource.Attribute{
+		Name: name,
+		List: &resource.ListAttribute{
+			ElementType:              elementType,
+			ComputedOptionalRequired: computability,
+			Description:              s.GetDescription(),
+			Validators:               s.buildListValidators(registry),
+		},
+	}, nil
+}
+
+func (s *OASSchema) buildCollectionDataSource(name string, computability schema.ComputedOptionalRequired, registry *ConstraintRegistry) (*datasource.Attribute, error) {
+	itemSchema := &OASSchema{Schema: s.Schema.Items.A.Schema()}
+	if itemSchema.isObjectType() {
+		return nil, fmt.Errorf("arrays of objects are not yet supported")
+	}
+
+	elementType, err := itemSchema.buildElementType()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build array element type - %w", err)
+	}
+
+	if DetermineNestingMode(s) == NestingModeSet {
+		return &datasource.Attribute{
+			Name: name,
+			Set: &datasource.SetAttribute{
+				ElementType:              elementType,
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+				Validators:               s.buildSetValidators(registry),
+			},
+		}, nil
+	}
+
+	return &datasource.Attribute{
+		Name: name,
+		List: &datasource.ListAttribute{
+			ElementType:              elementType,
+			ComputedOptionalRequired: computability,
+			Description:              s.GetDescription(),
+			Validators:               s.buildListValidators(registry),
+		},
+	}, nil
+}
+
+// buildElementType builds the schema.ElementType for a primitive list/set/map
+// element.
+func (s *OASSchema) buildElementType() (schema.ElementType, error) {
+	if s.Schema == nil || len(s.Schema.Type) == 0 {
+		return schema.ElementType{}, fmt.Errorf("schema has no type to build an element type from")
+	}
+
+	switch s.Schema.Type[0] {
+	case "string":
+		return schema.ElementType{String: &schema.StringType{}}, nil
+	case "boolean":
+		return schema.ElementType{Bool: &schema.BoolType{}}, nil
+	case "integer":
+		return schema.ElementType{Int64: &schema.Int64Type{}}, nil
+	case "number":
+		if s.Schema.Format == "float" || s.Schema.Format == "double" {
+			return schema.ElementType{Float64: &schema.Float64Type{}}, nil
+		}
+
+		return schema.ElementType{Number: &schema.NumberType{}}, nil
+	default:
+		return schema.ElementType{}, fmt.Errorf("type %q is not a supported element type", s.Schema.Type[0])
+	}
+}
+
+// buildPrimitiveResource builds a String/Bool/Int64/Float64/Number
+// resource.Attribute from a primitive schema, applying registry's custom
+// format override (falling back to the built-in pattern/enum validators) and
+// the writeOnly constraint where applicable.
+func (s *OASSchema) buildPrimitiveResource(name string, computability schema.ComputedOptionalRequired, registry *ConstraintRegistry) (*resource.Attribute, error) {
+	if s.Schema == nil || len(s.Schema.Type) == 0 {
+		return nil, fmt.Errorf("schema has no type")
+	}
+
+	switch s.Schema.Type[0] {
+	case "string":
+		var sensitive *bool
+		if s.Schema.Format == "password" {
+			sensitive = pointer(true)
+		}
+
+		return &resource.Attribute{
+			Name: name,
+			String: &resource.StringAttribute{
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+				Sensitive:                sensitive,
+				WriteOnly:                writeOnlyPointer(s.Schema.WriteOnly),
+				Validators:               s.buildStringValidators(registry),
+			},
+		}, nil
+	case "boolean":
+		return &resource.Attribute{
+			Name: name,
+			Bool: &resource.BoolAttribute{
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+				WriteOnly:                writeOnlyPointer(s.Schema.WriteOnly),
+			},
+		}, nil
+	case "integer":
+		return &resource.Attribute{
+			Name: name,
+			Int64: &resource.Int64Attribute{
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+				WriteOnly:                writeOnlyPointer(s.Schema.WriteOnly),
+			},
+		}, nil
+	case "number":
+		if s.Schema.Format == "float" || s.Schema.Format == "double" {
+			return &resource.Attribute{
+				Name: name,
+				Float64: &resource.Float64Attribute{
+					ComputedOptionalRequired: computability,
+					Description:              s.GetDescription(),
+					WriteOnly:                writeOnlyPointer(s.Schema.WriteOnly),
+				},
+			}, nil
+		}
+
+		return &resource.Attribute{
+			Name: name,
+			Number: &resource.NumberAttribute{
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+				WriteOnly:                writeOnlyPointer(s.Schema.WriteOnly),
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("type %q is not a supported primitive attribute", s.Schema.Type[0])
+	}
+}
+
+// writeOnlyPointer mirrors the existing Sensitive convention: the field is
+// only populated when true, so schemas that don't opt into writeOnly leave
+// it nil rather than an explicit false.
+func writeOnlyPointer(writeOnly bool) *bool {
+	if !writeOnly {
+		return nil
+	}
+
+	return pointer(true)
+}
+
+// buildPrimitiveDataSource mirrors buildPrimitiveResource for data sources.
+// Data sources have no plan, so writeOnly (a write-time-only concept) is not
+// applicable here.
+func (s *OASSchema) buildPrimitiveDataSource(name string, computability schema.ComputedOptionalRequired, registry *ConstraintRegistry) (*datasource.Attribute, error) {
+	if s.Schema == nil || len(s.Schema.Type) == 0 {
+		return nil, fmt.Errorf("schema has no type")
+	}
+
+	switch s.Schema.Type[0] {
+	case "string":
+		var sensitive *bool
+		if s.Schema.Format == "password" {
+			sensitive = pointer(true)
+		}
+
+		return &datasource.Attribute{
+			Name: name,
+			String: &datasource.StringAttribute{
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+				Sensitive:                sensitive,
+				Validators:               s.buildStringValidators(registry),
+			},
+		}, nil
+	case "boolean":
+		return &datasource.Attribute{
+			Name: name,
+			Bool: &datasource.BoolAttribute{
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+			},
+		}, nil
+	case "integer":
+		return &datasource.Attribute{
+			Name: name,
+			Int64: &datasource.Int64Attribute{
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+			},
+		}, nil
+	case "number":
+		if s.Schema.Format == "float" || s.Schema.Format == "double" {
+			return &datasource.Attribute{
+				Name: name,
+				Float64: &datasource.Float64Attribute{
+					ComputedOptionalRequired: computability,
+					Description:              s.GetDescription(),
+				},
+			}, nil
+		}
+
+		return &datasource.Attribute{
+			Name: name,
+			Number: &datasource.NumberAttribute{
+				ComputedOptionalRequired: computability,
+				Description:              s.GetDescription(),
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("type %q is not a supported primitive attribute", s.Schema.Type[0])
+	}
+}
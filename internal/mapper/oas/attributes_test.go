@@ -0,0 +1,67 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oas_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-codegen-openapi/internal/mapper/oas"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/resource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/schema"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// TestBuildResourceAttributes_nestingModeDispatch exercises
+// BuildResourceAttributes directly, proving that DetermineNestingMode drives
+// the List vs. Set decision for array-shaped properties from a real
+// top-level dispatch rather than only from its own unit test.
+func TestBuildResourceAttributes_nestingModeDispatch(t *testing.T) {
+	t.Parallel()
+
+	trueVal := true
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			Type: []string{"object"},
+			Properties: map[string]*base.SchemaProxy{
+				"tags": base.CreateSchemaProxy(&base.Schema{
+					Type:  []string{"array"},
+					Items: &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})},
+				}),
+				"unique_tags": base.CreateSchemaProxy(&base.Schema{
+					Type:        []string{"array"},
+					UniqueItems: &trueVal,
+					Items:       &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})},
+				}),
+			},
+		},
+	}
+
+	attributes, err := s.BuildResourceAttributes(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &[]resource.Attribute{
+		{
+			Name: "tags",
+			List: &resource.ListAttribute{
+				ElementType:              schema.ElementType{String: &schema.StringType{}},
+				ComputedOptionalRequired: schema.ComputedOptional,
+			},
+		},
+		{
+			Name: "unique_tags",
+			Set: &resource.SetAttribute{
+				ElementType:              schema.ElementType{String: &schema.StringType{}},
+				ComputedOptionalRequired: schema.ComputedOptional,
+			},
+		},
+	}
+
+	if diff := cmp.Diff(attributes, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,236 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oas
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-codegen-spec/code"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/schema"
+)
+
+// ConstraintRegistry maps OAS `format` values onto provider-supplied
+// validator packages, so a provider can override or extend the generator's
+// built-in translation of OAS constraints into validator references. A
+// format entry replaces the built-in validators (pattern, enum, minItems,
+// maxItems, uniqueItems) of a string, list, or set attribute whose schema
+// has that format; readOnly, writeOnly, minProperties, and maxProperties are
+// always translated by the built-ins. It is loaded from a JSON config
+// file via LoadConstraintRegistry; a nil registry falls back to the
+// generator's built-ins for every format.
+type ConstraintRegistry struct {
+	Formats map[string]CustomFormatValidator `json:"formats"`
+}
+
+// CustomFormatValidator is a single provider-supplied validator reference,
+// rendered verbatim as SchemaDefinition with Import added to the generated
+// file's imports.
+type CustomFormatValidator struct {
+	Import           string `json:"import"`
+	SchemaDefinition string `json:"schema_definition"`
+}
+
+// LoadConstraintRegistry reads a ConstraintRegistry from a JSON config file.
+func LoadConstraintRegistry(path string) (*ConstraintRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read constraint registry %q - %w", path, err)
+	}
+
+	var registry ConstraintRegistry
+	if err := json.Unmarshal(data, &registry); err != nil {
+		return nil, fmt.Errorf("failed to parse constraint registry %q - %w", path, err)
+	}
+
+	return &registry, nil
+}
+
+// customValidator looks up a provider-supplied validator for format in the
+// registry, returning nil, false when the registry is nil or has no entry
+// for format.
+func (r *ConstraintRegistry) customValidator(format string) (*schema.CustomValidator, bool) {
+	if r == nil {
+		return nil, false
+	}
+
+	custom, ok := r.Formats[format]
+	if !ok {
+		return nil, false
+	}
+
+	return &schema.CustomValidator{
+		Imports:          []code.Import{{Path: custom.Import}},
+		SchemaDefinition: custom.SchemaDefinition,
+	}, true
+}
+
+// buildObjectSizeValidators translates minProperties/maxProperties
+// constraints on an object schema into ObjectValidators. format is a
+// primitive-schema facet in OAS and never legitimately applies to an object,
+// so - unlike the leaf-level validator builders - this does not consult the
+// registry's format overrides; registry is accepted for a consistent
+// signature with the other constraint builders and reserved for a future
+// object-level override mechanism.
+func (s *OASSchema) buildObjectSizeValidators(_ *ConstraintRegistry) []schema.ObjectValidator {
+	var validators []schema.ObjectValidator
+
+	if s.Schema.MinProperties != nil {
+		validators = append(validators, schema.ObjectValidator{
+			Custom: &schema.CustomValidator{
+				Imports:          []code.Import{{Path: "github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"}},
+				SchemaDefinition: fmt.Sprintf("objectvalidator.SizeAtLeast(%d)", int(*s.Schema.MinProperties)),
+			},
+		})
+	}
+
+	if s.Schema.MaxProperties != nil {
+		validators = append(validators, schema.ObjectValidator{
+			Custom: &schema.CustomValidator{
+				Imports:          []code.Import{{Path: "github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"}},
+				SchemaDefinition: fmt.Sprintf("objectvalidator.SizeAtMost(%d)", int(*s.Schema.MaxProperties)),
+			},
+		})
+	}
+
+	return validators
+}
+
+// buildObjectPlanModifiers translates the readOnly constraint on an object
+// schema into ObjectPlanModifiers. writeOnly has no plan modifier equivalent
+// - it's surfaced instead as the WriteOnly field on the leaf attribute
+// builders in attributes.go, since write-only is an attribute-level concept.
+func (s *OASSchema) buildObjectPlanModifiers() []schema.ObjectPlanModifier {
+	var planModifiers []schema.ObjectPlanModifier
+
+	if s.Schema.ReadOnly {
+		planModifiers = append(planModifiers, schema.ObjectPlanModifier{
+			Custom: &schema.CustomPlanModifier{
+				Imports:          []code.Import{{Path: "github.com/hashicorp/terraform-plugin-framework/resource/schema/objectplanmodifier"}},
+				SchemaDefinition: "objectplanmodifier.UseStateForUnknown()",
+			},
+		})
+	}
+
+	return planModifiers
+}
+
+// buildStringValidators translates pattern/enum constraints on a string
+// schema into StringValidators, honoring a registry override for the
+// schema's format - unlike object schemas, format is a legitimate facet of a
+// string schema, so a registry override here takes precedence over the
+// built-in pattern/enum translation.
+func (s *OASSchema) buildStringValidators(registry *ConstraintRegistry) []schema.StringValidator {
+	if registry != nil {
+		if custom, ok := registry.customValidator(s.Schema.Format); ok {
+			return []schema.StringValidator{{Custom: custom}}
+		}
+	}
+
+	var validators []schema.StringValidator
+
+	if s.Schema.Pattern != "" {
+		validators = append(validators, schema.StringValidator{
+			Custom: &schema.CustomValidator{
+				Imports: []code.Import{
+					{Path: "regexp"},
+					{Path: "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"},
+				},
+				SchemaDefinition: fmt.Sprintf("stringvalidator.RegexMatches(regexp.MustCompile(%q), %q)", s.Schema.Pattern, fmt.Sprintf("must match pattern %s", s.Schema.Pattern)),
+			},
+		})
+	}
+
+	if len(s.Schema.Enum) > 0 {
+		quoted := make([]string, len(s.Schema.Enum))
+		for i, value := range s.Schema.Enum {
+			quoted[i] = fmt.Sprintf("%q", value.Value)
+		}
+
+		validators = append(validators, schema.StringValidator{
+			Custom: &schema.CustomValidator{
+				Imports:          []code.Import{{Path: "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"}},
+				SchemaDefinition: fmt.Sprintf("stringvalidator.OneOf(%s)", strings.Join(quoted, ", ")),
+			},
+		})
+	}
+
+	return validators
+}
+
+// buildListValidators translates minItems/maxItems/uniqueItems constraints
+// on an array schema into ListValidators.
+func (s *OASSchema) buildListValidators(registry *ConstraintRegistry) []schema.ListValidator {
+	if registry != nil {
+		if custom, ok := registry.customValidator(s.Schema.Format); ok {
+			return []schema.ListValidator{{Custom: custom}}
+		}
+	}
+
+	var validators []schema.ListValidator
+
+	if s.Schema.MinItems != nil {
+		validators = append(validators, schema.ListValidator{
+			Custom: &schema.CustomValidator{
+				Imports:          []code.Import{{Path: "github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"}},
+				SchemaDefinition: fmt.Sprintf("listvalidator.SizeAtLeast(%d)", int(*s.Schema.MinItems)),
+			},
+		})
+	}
+
+	if s.Schema.MaxItems != nil {
+		validators = append(validators, schema.ListValidator{
+			Custom: &schema.CustomValidator{
+				Imports:          []code.Import{{Path: "github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"}},
+				SchemaDefinition: fmt.Sprintf("listvalidator.SizeAtMost(%d)", int(*s.Schema.MaxItems)),
+			},
+		})
+	}
+
+	if s.Schema.UniqueItems != nil && *s.Schema.UniqueItems {
+		validators = append(validators, schema.ListValidator{
+			Custom: &schema.CustomValidator{
+				Imports:          []code.Import{{Path: "github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"}},
+				SchemaDefinition: "listvalidator.UniqueValues()",
+			},
+		})
+	}
+
+	return validators
+}
+
+// buildSetValidators translates minItems/maxItems constraints on an array
+// schema into SetValidators. uniqueItems needs no validator here - a Set
+// attribute already deduplicates its elements by construction.
+func (s *OASSchema) buildSetValidators(registry *ConstraintRegistry) []schema.SetValidator {
+	if registry != nil {
+		if custom, ok := registry.customValidator(s.Schema.Format); ok {
+			return []schema.SetValidator{{Custom: custom}}
+		}
+	}
+
+	var validators []schema.SetValidator
+
+	if s.Schema.MinItems != nil {
+		validators = append(validators, schema.SetValidator{
+			Custom: &schema.CustomValidator{
+				Imports:          []code.Import{{Path: "github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"}},
+				SchemaDefinition: fmt.Sprintf("setvalidator.SizeAtLeast(%d)", int(*s.Schema.MinItems)),
+			},
+		})
+	}
+
+	if s.Schema.MaxItems != nil {
+		validators = append(validators, schema.SetValidator{
+			Custom: &schema.CustomValidator{
+				Imports:          []code.Import{{Path: "github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"}},
+				SchemaDefinition: fmt.Sprintf("setvalidator.SizeAtMost(%d)", int(*s.Schema.MaxItems)),
+			},
+		})
+	}
+
+	return validators
+}
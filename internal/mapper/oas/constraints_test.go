@@ -0,0 +1,396 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oas_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/code"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/datasource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/resource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/schema"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"gopkg.in/yaml.v3"
+
+	"github.com/hashicorp/terraform-plugin-codegen-openapi/internal/mapper/oas"
+)
+
+func TestBuildSingleNestedResource_constraints(t *testing.T) {
+	t.Parallel()
+
+	minProperties := int64(1)
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			Type:          []string{"object"},
+			ReadOnly:      true,
+			MinProperties: &minProperties,
+			Properties: map[string]*base.SchemaProxy{
+				"name": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			},
+		},
+	}
+
+	attribute, err := s.BuildSingleNestedResource("nested_attr", schema.ComputedOptional, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(attribute.SingleNested.PlanModifiers) != 1 {
+		t.Fatalf("expected one plan modifier for readOnly schema, got %d", len(attribute.SingleNested.PlanModifiers))
+	}
+
+	expectedValidators := []schema.ObjectValidator{
+		{
+			Custom: &schema.CustomValidator{
+				Imports: []code.Import{
+					{Path: "github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"},
+				},
+				SchemaDefinition: "objectvalidator.SizeAtLeast(1)",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(attribute.SingleNested.Validators, expectedValidators); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+// TestConstraintRegistry_customFormatOverride exercises the registry's
+// format override on a string leaf attribute, reached through the real
+// attribute dispatcher's recursion - format is a primitive-schema facet in
+// OAS and never legitimately applies to an object, so the override is
+// surfaced on the leaf rather than on the enclosing nested object.
+func TestConstraintRegistry_customFormatOverride(t *testing.T) {
+	t.Parallel()
+
+	registry := &oas.ConstraintRegistry{
+		Formats: map[string]oas.CustomFormatValidator{
+			"cidr": {
+				Import:           "example.com/provider/validators/cidrvalidator",
+				SchemaDefinition: "cidrvalidator.Valid()",
+			},
+		},
+	}
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			Type: []string{"object"},
+			Properties: map[string]*base.SchemaProxy{
+				"subnet": base.CreateSchemaProxy(&base.Schema{
+					Type:   []string{"string"},
+					Format: "cidr",
+				}),
+			},
+		},
+	}
+
+	attributes, err := s.BuildResourceAttributes(registry)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedValidators := []schema.StringValidator{
+		{
+			Custom: &schema.CustomValidator{
+				Imports: []code.Import{
+					{Path: "example.com/provider/validators/cidrvalidator"},
+				},
+				SchemaDefinition: "cidrvalidator.Valid()",
+			},
+		},
+	}
+
+	if len(*attributes) != 1 || (*attributes)[0].String == nil {
+		t.Fatalf("expected a single string attribute, got %+v", *attributes)
+	}
+
+	if diff := cmp.Diff((*attributes)[0].String.Validators, expectedValidators); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+// TestBuildResourceAttributes_leafConstraints covers the writeOnly,
+// pattern, enum, minItems, maxItems, and uniqueItems constraints threaded
+// through the dispatcher onto leaf attributes - including a leaf nested
+// inside a MapNestedAttribute's NestedObject, proving the recursion reaches
+// every leaf, not just top-level properties.
+func TestBuildResourceAttributes_leafConstraints(t *testing.T) {
+	t.Parallel()
+
+	minItems := int64(1)
+	maxItems := int64(5)
+	uniqueItems := true
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			Type: []string{"object"},
+			Properties: map[string]*base.SchemaProxy{
+				"secret": base.CreateSchemaProxy(&base.Schema{
+					Type:      []string{"string"},
+					WriteOnly: true,
+				}),
+				"code": base.CreateSchemaProxy(&base.Schema{
+					Type:    []string{"string"},
+					Pattern: "^[A-Z]{3}$",
+				}),
+				"color": base.CreateSchemaProxy(&base.Schema{
+					Type: []string{"string"},
+					Enum: []*yaml.Node{{Value: "red"}, {Value: "blue"}},
+				}),
+				"tags": base.CreateSchemaProxy(&base.Schema{
+					Type:        []string{"array"},
+					MinItems:    &minItems,
+					MaxItems:    &maxItems,
+					UniqueItems: &uniqueItems,
+					Items:       &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})},
+				}),
+				"labels": base.CreateSchemaProxy(&base.Schema{
+					Type: []string{"object"},
+					AdditionalProperties: base.CreateSchemaProxy(&base.Schema{
+						Type:     []string{"object"},
+						Required: []string{"value"},
+						Properties: map[string]*base.SchemaProxy{
+							"value": base.CreateSchemaProxy(&base.Schema{
+								Type:    []string{"string"},
+								Pattern: "^[a-z]+$",
+							}),
+						},
+					}),
+				}),
+			},
+		},
+	}
+
+	attributes, err := s.BuildResourceAttributes(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	byName := map[string]resource.Attribute{}
+	for _, attribute := range *attributes {
+		byName[attribute.Name] = attribute
+	}
+
+	secret := byName["secret"]
+	if secret.String == nil || secret.String.WriteOnly == nil || !*secret.String.WriteOnly {
+		t.Errorf("expected secret to be writeOnly, got %+v", secret)
+	}
+
+	code := byName["code"]
+	if code.String == nil || len(code.String.Validators) != 1 {
+		t.Errorf("expected code to have one pattern validator, got %+v", code)
+	}
+
+	color := byName["color"]
+	if color.String == nil || len(color.String.Validators) != 1 {
+		t.Errorf("expected color to have one enum validator, got %+v", color)
+	}
+
+	tags := byName["tags"]
+	if tags.Set == nil || len(tags.Set.Validators) != 2 {
+		t.Errorf("expected tags (uniqueItems) to be a set with minItems/maxItems validators, got %+v", tags)
+	}
+
+	labels := byName["labels"]
+	if labels.MapNested == nil || len(labels.MapNested.NestedObject.Attributes) != 1 {
+		t.Fatalf("expected labels to be a map nested attribute, got %+v", labels)
+	}
+
+	nestedValue := labels.MapNested.NestedObject.Attributes[0]
+	if nestedValue.String == nil || len(nestedValue.String.Validators) != 1 {
+		t.Errorf("expected the leaf inside MapNestedAttribute.NestedObject.Attributes to get its pattern validator, got %+v", nestedValue)
+	}
+}
+
+// TestBuildSingleNestedDataSource_constraints mirrors
+// TestBuildSingleNestedResource_constraints for the DataSource builder.
+func TestBuildSingleNestedDataSource_constraints(t *testing.T) {
+	t.Parallel()
+
+	minProperties := int64(1)
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			Type:          []string{"object"},
+			MinProperties: &minProperties,
+			Properties: map[string]*base.SchemaProxy{
+				"name": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			},
+		},
+	}
+
+	attribute, err := s.BuildSingleNestedDataSource("nested_attr", schema.ComputedOptional, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedValidators := []schema.ObjectValidator{
+		{
+			Custom: &schema.CustomValidator{
+				Imports: []code.Import{
+					{Path: "github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"},
+				},
+				SchemaDefinition: "objectvalidator.SizeAtLeast(1)",
+			},
+		},
+	}
+
+	if diff := cmp.Diff(attribute.SingleNested.Validators, expectedValidators); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+// TestConstraintRegistry_customFormatOverrideDataSource mirrors
+// TestConstraintRegistry_customFormatOverride for the DataSource dispatcher,
+// and also proves the override propagates into a MapNestedAttribute's
+// NestedObject on the DataSource path.
+func TestConstraintRegistry_customFormatOverrideDataSource(t *testing.T) {
+	t.Parallel()
+
+	registry := &oas.ConstraintRegistry{
+		Formats: map[string]oas.CustomFormatValidator{
+			"cidr": {
+				Import:           "example.com/provider/validators/cidrvalidator",
+				SchemaDefinition: "cidrvalidator.Valid()",
+			},
+		},
+	}
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			Type: []string{"object"},
+			Properties: map[string]*base.SchemaProxy{
+				"labels": base.CreateSchemaProxy(&base.Schema{
+					Type: []string{"object"},
+					AdditionalProperties: base.CreateSchemaProxy(&base.Schema{
+						Type: []string{"object"},
+						Properties: map[string]*base.SchemaProxy{
+							"subnet": base.CreateSchemaProxy(&base.Schema{
+								Type:   []string{"string"},
+								Format: "cidr",
+							}),
+						},
+					}),
+				}),
+			},
+		},
+	}
+
+	attributes, err := s.BuildDataSourceAttributes(registry)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expectedValidators := []schema.StringValidator{
+		{
+			Custom: &schema.CustomValidator{
+				Imports: []code.Import{
+					{Path: "example.com/provider/validators/cidrvalidator"},
+				},
+				SchemaDefinition: "cidrvalidator.Valid()",
+			},
+		},
+	}
+
+	if len(*attributes) != 1 || (*attributes)[0].MapNested == nil {
+		t.Fatalf("expected a single map nested attribute, got %+v", *attributes)
+	}
+
+	nestedValue := (*attributes)[0].MapNested.NestedObject.Attributes[0]
+	if nestedValue.String == nil {
+		t.Fatalf("expected the leaf inside MapNestedAttribute.NestedObject.Attributes to be a string, got %+v", nestedValue)
+	}
+
+	if diff := cmp.Diff(nestedValue.String.Validators, expectedValidators); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+// TestBuildDataSourceAttributes_leafConstraints mirrors
+// TestBuildResourceAttributes_leafConstraints for the DataSource dispatcher -
+// data sources have no writeOnly concept, so this covers pattern, enum, and
+// minItems/maxItems/uniqueItems instead.
+func TestBuildDataSourceAttributes_leafConstraints(t *testing.T) {
+	t.Parallel()
+
+	minItems := int64(1)
+	maxItems := int64(5)
+	uniqueItems := true
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			Type: []string{"object"},
+			Properties: map[string]*base.SchemaProxy{
+				"code": base.CreateSchemaProxy(&base.Schema{
+					Type:    []string{"string"},
+					Pattern: "^[A-Z]{3}$",
+				}),
+				"color": base.CreateSchemaProxy(&base.Schema{
+					Type: []string{"string"},
+					Enum: []*yaml.Node{{Value: "red"}, {Value: "blue"}},
+				}),
+				"tags": base.CreateSchemaProxy(&base.Schema{
+					Type:        []string{"array"},
+					MinItems:    &minItems,
+					MaxItems:    &maxItems,
+					UniqueItems: &uniqueItems,
+					Items:       &base.DynamicValue[*base.SchemaProxy, bool]{A: base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}})},
+				}),
+			},
+		},
+	}
+
+	attributes, err := s.BuildDataSourceAttributes(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	byName := map[string]datasource.Attribute{}
+	for _, attribute := range *attributes {
+		byName[attribute.Name] = attribute
+	}
+
+	code := byName["code"]
+	if code.String == nil || len(code.String.Validators) != 1 {
+		t.Errorf("expected code to have one pattern validator, got %+v", code)
+	}
+
+	color := byName["color"]
+	if color.String == nil || len(color.String.Validators) != 1 {
+		t.Errorf("expected color to have one enum validator, got %+v", color)
+	}
+
+	tags := byName["tags"]
+	if tags.Set == nil || len(tags.Set.Validators) != 2 {
+		t.Errorf("expected tags (uniqueItems) to be a set with minItems/maxItems validators, got %+v", tags)
+	}
+}
+
+func TestBuildSingleNestedResource_noConstraints(t *testing.T) {
+	t.Parallel()
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			Type: []string{"object"},
+			Properties: map[string]*base.SchemaProxy{
+				"name": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			},
+		},
+	}
+
+	attribute, err := s.BuildSingleNestedResource("nested_attr", schema.ComputedOptional, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(attribute.SingleNested.PlanModifiers) != 0 {
+		t.Errorf("expected no plan modifiers, got %d", len(attribute.SingleNested.PlanModifiers))
+	}
+
+	if len(attribute.SingleNested.Validators) != 0 {
+		t.Errorf("expected no validators, got %d", len(attribute.SingleNested.Validators))
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oas
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-codegen-spec/code"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/datasource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/resource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/schema"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+// defaultDiscriminatorName is used for the companion discriminator attribute
+// when the schema declares oneOf/anyOf without an explicit discriminator.
+const defaultDiscriminatorName = "discriminator"
+
+// HasPolymorphism returns true when the schema is a oneOf, anyOf, or
+// discriminated union that BuildDynamicNestedResource/DataSource should
+// handle instead of BuildSingleNestedResource/DataSource.
+func (s *OASSchema) HasPolymorphism() bool {
+	if s.Schema == nil {
+		return false
+	}
+
+	return len(s.Schema.OneOf) > 0 || len(s.Schema.AnyOf) > 0 || s.Schema.Discriminator != nil
+}
+
+// polymorphicVariants returns the subschemas of a oneOf/anyOf schema, preferring oneOf.
+func (s *OASSchema) polymorphicVariants() []*base.SchemaProxy {
+	if len(s.Schema.OneOf) > 0 {
+		return s.Schema.OneOf
+	}
+
+	return s.Schema.AnyOf
+}
+
+// discriminatorName returns the discriminator field name, falling back to
+// defaultDiscriminatorName when the schema has no explicit discriminator.
+func (s *OASSchema) discriminatorName() string {
+	if s.Schema.Discriminator != nil && s.Schema.Discriminator.PropertyName != "" {
+		return s.Schema.Discriminator.PropertyName
+	}
+
+	return defaultDiscriminatorName
+}
+
+// discriminatorVariantNames returns the discriminator's mapping keys (the
+// variant names a `petType`-style field can take), falling back to the
+// variant schemas' titles when there is no explicit mapping.
+func (s *OASSchema) discriminatorVariantNames() []string {
+	if s.Schema.Discriminator != nil && len(s.Schema.Discriminator.Mapping) > 0 {
+		names := make([]string, 0, len(s.Schema.Discriminator.Mapping))
+		for variant := range s.Schema.Discriminator.Mapping {
+			names = append(names, variant)
+		}
+		sort.Strings(names)
+
+		return names
+	}
+
+	var names []string
+	for _, variant := range s.polymorphicVariants() {
+		if resolved := variant.Schema(); resolved != nil && resolved.Title != "" {
+			names = append(names, resolved.Title)
+		}
+	}
+
+	return names
+}
+
+// attributeTypeTag identifies which typed field of a resource/datasource
+// Attribute is populated (e.g. "String", "Int64", "SingleNested"), so variants
+// can be compared for type agreement without hardcoding the attribute kind set.
+func attributeTypeTag(attribute any) string {
+	value := reflect.ValueOf(attribute)
+	valueType := value.Type()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if field.Name == "Name" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+			return field.Name
+		}
+	}
+
+	return ""
+}
+
+// attributeComputedOptionalRequired reads the ComputedOptionalRequired value
+// out of whichever typed field (String, Int64, SingleNested, ...) an
+// Attribute has populated.
+func attributeComputedOptionalRequired(attribute any) schema.ComputedOptionalRequired {
+	tag := attributeTypeTag(attribute)
+	if tag == "" {
+		return ""
+	}
+
+	typedField := reflect.ValueOf(attribute).FieldByName(tag)
+	if typedField.IsNil() {
+		return ""
+	}
+
+	corField := typedField.Elem().FieldByName("ComputedOptionalRequired")
+	if !corField.IsValid() {
+		return ""
+	}
+
+	cor, _ := corField.Interface().(schema.ComputedOptionalRequired)
+
+	return cor
+}
+
+// markAttributeOptional overrides the ComputedOptionalRequired value of
+// whichever typed field an Attribute has populated to schema.ComputedOptional,
+// used when a union field isn't present (or isn't required) in every variant.
+func markAttributeOptional(attribute *resource.Attribute) {
+	tag := attributeTypeTag(*attribute)
+	if tag == "" {
+		return
+	}
+
+	typedField := reflect.ValueOf(attribute).Elem().FieldByName(tag)
+	if typedField.IsNil() {
+		return
+	}
+
+	corField := typedField.Elem().FieldByName("ComputedOptionalRequired")
+	if corField.IsValid() && corField.CanSet() {
+		corField.Set(reflect.ValueOf(schema.ComputedOptional))
+	}
+}
+
+func markDataSourceAttributeOptional(attribute *datasource.Attribute) {
+	tag := attributeTypeTag(*attribute)
+	if tag == "" {
+		return
+	}
+
+	typedField := reflect.ValueOf(attribute).Elem().FieldByName(tag)
+	if typedField.IsNil() {
+		return
+	}
+
+	corField := typedField.Elem().FieldByName("ComputedOptionalRequired")
+	if corField.IsValid() && corField.CanSet() {
+		corField.Set(reflect.ValueOf(schema.ComputedOptional))
+	}
+}
+
+// oneOfValidator renders a stringvalidator.OneOf(...) custom validator
+// listing the given variant names.
+func oneOfValidator(variants []string) *schema.CustomValidator {
+	quoted := make([]string, len(variants))
+	for i, variant := range variants {
+		quoted[i] = fmt.Sprintf("%q", variant)
+	}
+
+	return &schema.CustomValidator{
+		Imports:          []code.Import{{Path: "github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"}},
+		SchemaDefinition: fmt.Sprintf("stringvalidator.OneOf(%s)", strings.Join(quoted, ", ")),
+	}
+}
+
+// unionAttribute accumulates how a single union field appeared across
+// variants: whether it was present and required in every variant seen so
+// far, and whether its Go type stayed consistent.
+type unionAttribute[T any] struct {
+	attribute     T
+	seenCount     int
+	requiredCount int
+	typesDiffer   bool
+}
+
+// BuildDynamicNestedResource builds a resource.Attribute for a oneOf/anyOf/
+// discriminated schema. Fields present in every variant keep their
+// variant-declared requiredness; fields missing from some variants, or whose
+// requiredness disagrees across the variants it does appear in, are forced
+// Optional. A field that is consistently non-required (e.g. Computed in
+// every variant) keeps that requiredness rather than being forced Optional.
+// Fields whose type disagrees across variants fall back to a Dynamic
+// attribute. A companion discriminator string attribute is added with a
+// OneOf validator listing the variant names.
+func (s *OASSchema) BuildDynamicNestedResource(name string, computability schema.ComputedOptionalRequired) (*resource.Attribute, error) {
+	variants := s.polymorphicVariants()
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("schema has no oneOf/anyOf variants to build a dynamic nested attribute from")
+	}
+
+	union := map[string]*unionAttribute[resource.Attribute]{}
+	var order []string
+
+	for _, variantProxy := range variants {
+		variantSchema := variantProxy.Schema()
+		if variantSchema == nil {
+			continue
+		}
+
+		variantAttributes, err := (&OASSchema{Schema: variantSchema}).BuildResourceAttributes(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build oneOf/anyOf variant schema - %w", err)
+		}
+
+		for _, attribute := range *variantAttributes {
+			entry, ok := union[attribute.Name]
+			if !ok {
+				order = append(order, attribute.Name)
+				entry = &unionAttribute[resource.Attribute]{attribute: attribute}
+				union[attribute.Name] = entry
+			} else if attributeTypeTag(entry.attribute) != attributeTypeTag(attribute) {
+				entry.typesDiffer = true
+			}
+
+			entry.seenCount++
+			if attributeComputedOptionalRequired(attribute) == schema.Required {
+				entry.requiredCount++
+			}
+		}
+	}
+
+	attributes := make([]resource.Attribute, 0, len(order)+1)
+	for _, attrName := range order {
+		// The discriminator field is re-added below with a OneOf validator,
+		// regardless of whether the variants also declared it as a property.
+		if attrName == s.discriminatorName() {
+			continue
+		}
+
+		entry := union[attrName]
+		attribute := entry.attribute
+
+		switch {
+		case entry.typesDiffer:
+			attribute = resource.Attribute{
+				Name: attrName,
+				Dynamic: &resource.DynamicAttribute{
+					ComputedOptionalRequired: schema.ComputedOptional,
+					Description:              pointer(fmt.Sprintf("variant field %q differs in type across %s", attrName, s.discriminatorName())),
+				},
+			}
+		case entry.seenCount < len(variants) || (0 < entry.requiredCount && entry.requiredCount < entry.seenCount):
+			markAttributeOptional(&attribute)
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	attributes = append(attributes, resource.Attribute{
+		Name: s.discriminatorName(),
+		String: &resource.StringAttribute{
+			ComputedOptionalRequired: schema.Required,
+			Description:              pointer(fmt.Sprintf("Discriminator field. One of: %s", strings.Join(s.discriminatorVariantNames(), ", "))),
+			Validators: []schema.StringValidator{
+				{Custom: oneOfValidator(s.discriminatorVariantNames())},
+			},
+		},
+	})
+
+	return &resource.Attribute{
+		Name: name,
+		SingleNested: &resource.SingleNestedAttribute{
+			Attributes:               attributes,
+			ComputedOptionalRequired: computability,
+			Description:              s.GetDescription(),
+		},
+	}, nil
+}
+
+// BuildDynamicNestedDataSource builds a datasource.Attribute for a oneOf/anyOf/
+// discriminated schema. See BuildDynamicNestedResource for the merge rules.
+func (s *OASSchema) BuildDynamicNestedDataSource(name string, computability schema.ComputedOptionalRequired) (*datasource.Attribute, error) {
+	variants := s.polymorphicVariants()
+	if len(variants) == 0 {
+		return nil, fmt.Errorf("schema has no oneOf/anyOf variants to build a dynamic nested attribute from")
+	}
+
+	union := map[string]*unionAttribute[datasource.Attribute]{}
+	var order []string
+
+	for _, variantProxy := range variants {
+		variantSchema := variantProxy.Schema()
+		if variantSchema == nil {
+			continue
+		}
+
+		variantAttributes, err := (&OASSchema{Schema: variantSchema}).BuildDataSourceAttributes(nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build oneOf/anyOf variant schema - %w", err)
+		}
+
+		for _, attribute := range *variantAttributes {
+			entry, ok := union[attribute.Name]
+			if !ok {
+				order = append(order, attribute.Name)
+				entry = &unionAttribute[datasource.Attribute]{attribute: attribute}
+				union[attribute.Name] = entry
+			} else if attributeTypeTag(entry.attribute) != attributeTypeTag(attribute) {
+				entry.typesDiffer = true
+			}
+
+			entry.seenCount++
+			if attributeComputedOptionalRequired(attribute) == schema.Required {
+				entry.requiredCount++
+			}
+		}
+	}
+
+	attributes := make([]datasource.Attribute, 0, len(order)+1)
+	for _, attrName := range order {
+		// The discriminator field is re-added below with a OneOf validator,
+		// regardless of whether the variants also declared it as a property.
+		if attrName == s.discriminatorName() {
+			continue
+		}
+
+		entry := union[attrName]
+		attribute := entry.attribute
+
+		switch {
+		case entry.typesDiffer:
+			attribute = datasource.Attribute{
+				Name: attrName,
+				Dynamic: &datasource.DynamicAttribute{
+					ComputedOptionalRequired: schema.ComputedOptional,
+					Description:              pointer(fmt.Sprintf("variant field %q differs in type across %s", attrName, s.discriminatorName())),
+				},
+			}
+		case entry.seenCount < len(variants) || (0 < entry.requiredCount && entry.requiredCount < entry.seenCount):
+			markDataSourceAttributeOptional(&attribute)
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	attributes = append(attributes, datasource.Attribute{
+		Name: s.discriminatorName(),
+		String: &datasource.StringAttribute{
+			ComputedOptionalRequired: schema.Required,
+			Description:              pointer(fmt.Sprintf("Discriminator field. One of: %s", strings.Join(s.discriminatorVariantNames(), ", "))),
+			Validators: []schema.StringValidator{
+				{Custom: oneOfValidator(s.discriminatorVariantNames())},
+			},
+		},
+	})
+
+	return &datasource.Attribute{
+		Name: name,
+		SingleNested: &datasource.SingleNestedAttribute{
+			Attributes:               attributes,
+			ComputedOptionalRequired: computability,
+			Description:              s.GetDescription(),
+		},
+	}, nil
+}
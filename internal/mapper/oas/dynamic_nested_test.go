@@ -0,0 +1,311 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oas_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-codegen-openapi/internal/mapper/oas"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/schema"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+)
+
+func TestBuildDynamicNestedResource_petTypeDiscriminator(t *testing.T) {
+	t.Parallel()
+
+	dog := base.CreateSchemaProxy(&base.Schema{
+		Type:     []string{"object"},
+		Required: []string{"petType", "breed"},
+		Properties: map[string]*base.SchemaProxy{
+			"petType": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			"breed":   base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+		},
+	})
+
+	cat := base.CreateSchemaProxy(&base.Schema{
+		Type:     []string{"object"},
+		Required: []string{"petType", "livesLeft"},
+		Properties: map[string]*base.SchemaProxy{
+			"petType":   base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			"livesLeft": base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}, Format: "int64"}),
+		},
+	})
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			OneOf: []*base.SchemaProxy{dog, cat},
+			Discriminator: &base.Discriminator{
+				PropertyName: "petType",
+				Mapping: map[string]string{
+					"dog": "#/components/schemas/Dog",
+					"cat": "#/components/schemas/Cat",
+				},
+			},
+		},
+	}
+
+	if !s.HasPolymorphism() {
+		t.Fatalf("expected schema with oneOf+discriminator to report HasPolymorphism")
+	}
+
+	attribute, err := s.BuildDynamicNestedResource("pet", schema.ComputedOptional)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	byName := map[string]bool{}
+	for _, attr := range attribute.SingleNested.Attributes {
+		byName[attr.Name] = true
+
+		switch attr.Name {
+		case "petType":
+			if attr.String == nil || attr.String.ComputedOptionalRequired != schema.Required || len(attr.String.Validators) != 1 {
+				t.Errorf("expected petType to be a required string with a OneOf validator, got %+v", attr)
+			}
+		case "breed", "livesLeft":
+			cor := schema.ComputedOptionalRequired("")
+			switch {
+			case attr.String != nil:
+				cor = attr.String.ComputedOptionalRequired
+			case attr.Int64 != nil:
+				cor = attr.Int64.ComputedOptionalRequired
+			}
+
+			if cor != schema.ComputedOptional {
+				t.Errorf("expected variant-only field %q to be optional, got %s", attr.Name, cor)
+			}
+		case "discriminator":
+			t.Errorf("expected no separate discriminator attribute when the schema names its discriminator property, got %+v", attr)
+		}
+	}
+
+	for _, expected := range []string{"petType", "breed", "livesLeft"} {
+		if !byName[expected] {
+			t.Errorf("expected attribute %q in merged union, got %v", expected, byName)
+		}
+	}
+}
+
+// TestBuildDynamicNestedResource_uniformlyNonRequiredFieldStaysComputedOptional
+// ensures a field present in every variant and required in none keeps the
+// ComputedOptional requiredness the variants give it - only a field missing
+// from some variant, or whose requiredness disagrees across the variants it
+// appears in, is forced Optional. readOnly is not mapped to Computed by the
+// attribute builders, so the readOnly id here is ComputedOptional in each
+// variant.
+func TestBuildDynamicNestedResource_uniformlyNonRequiredFieldStaysComputedOptional(t *testing.T) {
+	t.Parallel()
+
+	dog := base.CreateSchemaProxy(&base.Schema{
+		Type:     []string{"object"},
+		Required: []string{"petType"},
+		Properties: map[string]*base.SchemaProxy{
+			"petType": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			"id":      base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}, ReadOnly: true}),
+		},
+	})
+
+	cat := base.CreateSchemaProxy(&base.Schema{
+		Type:     []string{"object"},
+		Required: []string{"petType"},
+		Properties: map[string]*base.SchemaProxy{
+			"petType": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			"id":      base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}, ReadOnly: true}),
+		},
+	})
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			OneOf: []*base.SchemaProxy{dog, cat},
+			Discriminator: &base.Discriminator{
+				PropertyName: "petType",
+				Mapping: map[string]string{
+					"dog": "#/components/schemas/Dog",
+					"cat": "#/components/schemas/Cat",
+				},
+			},
+		},
+	}
+
+	attribute, err := s.BuildDynamicNestedResource("pet", schema.ComputedOptional)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, attr := range attribute.SingleNested.Attributes {
+		if attr.Name != "id" {
+			continue
+		}
+
+		if attr.String == nil || attr.String.ComputedOptionalRequired != schema.ComputedOptional {
+			t.Errorf("expected id to stay ComputedOptional (never required in any variant), got %+v", attr)
+		}
+	}
+}
+
+// TestBuildDynamicNestedDataSource_petTypeDiscriminator mirrors
+// TestBuildDynamicNestedResource_petTypeDiscriminator for the DataSource
+// builder, which has identical merge rules but its own union/markOptional
+// code path.
+func TestBuildDynamicNestedDataSource_petTypeDiscriminator(t *testing.T) {
+	t.Parallel()
+
+	dog := base.CreateSchemaProxy(&base.Schema{
+		Type:     []string{"object"},
+		Required: []string{"petType", "breed"},
+		Properties: map[string]*base.SchemaProxy{
+			"petType": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			"breed":   base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+		},
+	})
+
+	cat := base.CreateSchemaProxy(&base.Schema{
+		Type:     []string{"object"},
+		Required: []string{"petType", "livesLeft"},
+		Properties: map[string]*base.SchemaProxy{
+			"petType":   base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			"livesLeft": base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}, Format: "int64"}),
+		},
+	})
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			OneOf: []*base.SchemaProxy{dog, cat},
+			Discriminator: &base.Discriminator{
+				PropertyName: "petType",
+				Mapping: map[string]string{
+					"dog": "#/components/schemas/Dog",
+					"cat": "#/components/schemas/Cat",
+				},
+			},
+		},
+	}
+
+	attribute, err := s.BuildDynamicNestedDataSource("pet", schema.ComputedOptional)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	byName := map[string]bool{}
+	for _, attr := range attribute.SingleNested.Attributes {
+		byName[attr.Name] = true
+
+		switch attr.Name {
+		case "petType":
+			if attr.String == nil || attr.String.ComputedOptionalRequired != schema.Required || len(attr.String.Validators) != 1 {
+				t.Errorf("expected petType to be a required string with a OneOf validator, got %+v", attr)
+			}
+		case "breed", "livesLeft":
+			cor := schema.ComputedOptionalRequired("")
+			switch {
+			case attr.String != nil:
+				cor = attr.String.ComputedOptionalRequired
+			case attr.Int64 != nil:
+				cor = attr.Int64.ComputedOptionalRequired
+			}
+
+			if cor != schema.ComputedOptional {
+				t.Errorf("expected variant-only field %q to be optional, got %s", attr.Name, cor)
+			}
+		case "discriminator":
+			t.Errorf("expected no separate discriminator attribute when the schema names its discriminator property, got %+v", attr)
+		}
+	}
+
+	for _, expected := range []string{"petType", "breed", "livesLeft"} {
+		if !byName[expected] {
+			t.Errorf("expected attribute %q in merged union, got %v", expected, byName)
+		}
+	}
+}
+
+// TestBuildDynamicNestedResource_typesDifferFallsBackToDynamic proves that a
+// field whose type disagrees across variants (a string in one, an integer in
+// another) falls back to a Dynamic attribute rather than keeping either
+// variant's concrete type.
+func TestBuildDynamicNestedResource_typesDifferFallsBackToDynamic(t *testing.T) {
+	t.Parallel()
+
+	withStringID := base.CreateSchemaProxy(&base.Schema{
+		Type:     []string{"object"},
+		Required: []string{"petType"},
+		Properties: map[string]*base.SchemaProxy{
+			"petType": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			"id":      base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+		},
+	})
+
+	withIntID := base.CreateSchemaProxy(&base.Schema{
+		Type:     []string{"object"},
+		Required: []string{"petType"},
+		Properties: map[string]*base.SchemaProxy{
+			"petType": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			"id":      base.CreateSchemaProxy(&base.Schema{Type: []string{"integer"}, Format: "int64"}),
+		},
+	})
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			OneOf: []*base.SchemaProxy{withStringID, withIntID},
+			Discriminator: &base.Discriminator{
+				PropertyName: "petType",
+				Mapping: map[string]string{
+					"dog": "#/components/schemas/Dog",
+					"cat": "#/components/schemas/Cat",
+				},
+			},
+		},
+	}
+
+	attribute, err := s.BuildDynamicNestedResource("pet", schema.ComputedOptional)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, attr := range attribute.SingleNested.Attributes {
+		if attr.Name != "id" {
+			continue
+		}
+
+		if attr.Dynamic == nil {
+			t.Errorf("expected id to fall back to a Dynamic attribute when its type differs across variants, got %+v", attr)
+		}
+	}
+}
+
+// TestBuildResourceAttributes_polymorphismDispatch proves that a oneOf
+// property reaches HasPolymorphism and BuildDynamicNestedResource from the
+// real attribute dispatcher, not just from direct calls in this file's other
+// tests.
+func TestBuildResourceAttributes_polymorphismDispatch(t *testing.T) {
+	t.Parallel()
+
+	dog := base.CreateSchemaProxy(&base.Schema{
+		Type:     []string{"object"},
+		Required: []string{"petType"},
+		Properties: map[string]*base.SchemaProxy{
+			"petType": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+		},
+	})
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			Type: []string{"object"},
+			Properties: map[string]*base.SchemaProxy{
+				"pet": base.CreateSchemaProxy(&base.Schema{
+					OneOf: []*base.SchemaProxy{dog},
+				}),
+			},
+		},
+	}
+
+	attributes, err := s.BuildResourceAttributes(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(*attributes) != 1 || (*attributes)[0].SingleNested == nil {
+		t.Fatalf("expected dispatcher to route the oneOf property to BuildDynamicNestedResource, got %+v", *attributes)
+	}
+}
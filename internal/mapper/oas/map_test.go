@@ -160,7 +160,7 @@ func TestBuildMapResource(t *testing.T) {
 			t.Parallel()
 
 			schema := oas.OASSchema{Schema: testCase.schema}
-			attributes, err := schema.BuildResourceAttributes()
+			attributes, err := schema.BuildResourceAttributes(nil)
 			if err != nil {
 				t.Fatalf("unexpected error: %s", err)
 			}
@@ -317,7 +317,7 @@ func TestBuildMapDataSource(t *testing.T) {
 			t.Parallel()
 
 			schema := oas.OASSchema{Schema: testCase.schema}
-			attributes, err := schema.BuildDataSourceAttributes()
+			attributes, err := schema.BuildDataSourceAttributes(nil)
 			if err != nil {
 				t.Fatalf("unexpected error: %s", err)
 			}
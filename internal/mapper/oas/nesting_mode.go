@@ -0,0 +1,93 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oas
+
+// NestingMode records which of Terraform protocol v6's nested attribute
+// representations (single, list, set, map - see tfprotov6.SchemaObjectNestingMode)
+// an OAS object/array shape maps onto. It is derived once per schema via
+// DetermineNestingMode and used by the attribute dispatcher in attributes.go
+// to decide which Build*Nested* builder applies, so downstream consumers
+// (e.g. internal/mapper/protoconvert) can emit attribute-style nested blocks
+// instead of falling back to legacy block syntax.
+type NestingMode int
+
+const (
+	// NestingModeSingle is a plain nested object (OAS object, no additionalProperties).
+	NestingModeSingle NestingMode = iota
+	// NestingModeList is an ordered collection (OAS array without uniqueItems).
+	NestingModeList
+	// NestingModeSet is an unordered, deduplicated collection (OAS array with uniqueItems: true).
+	NestingModeSet
+	// NestingModeMap is a string-keyed collection (OAS object with additionalProperties).
+	NestingModeMap
+)
+
+func (n NestingMode) String() string {
+	switch n {
+	case NestingModeSingle:
+		return "single"
+	case NestingModeList:
+		return "list"
+	case NestingModeSet:
+		return "set"
+	case NestingModeMap:
+		return "map"
+	default:
+		return "unknown"
+	}
+}
+
+// extNestingMode lets providers override the nesting mode the generator
+// would otherwise infer from the OAS shape, e.g. to force a `set` onto an
+// array that OAS cannot mark with uniqueItems: true for transport reasons.
+const extNestingMode = "x-terraform-nesting-mode"
+
+// DetermineNestingMode derives a NestingMode from an OAS schema's shape:
+//
+//	array + uniqueItems: true  -> NestingModeSet
+//	array                      -> NestingModeList
+//	object + additionalProperties -> NestingModeMap
+//	object                     -> NestingModeSingle
+//
+// An extNestingMode extension on the schema takes precedence over the
+// inferred value.
+func DetermineNestingMode(s *OASSchema) NestingMode {
+	if s != nil && s.Schema != nil && s.Schema.Extensions != nil {
+		if extension, ok := s.Schema.Extensions.Get(extNestingMode); ok && extension != nil {
+			switch extension.Value {
+			case "single":
+				return NestingModeSingle
+			case "list":
+				return NestingModeList
+			case "set":
+				return NestingModeSet
+			case "map":
+				return NestingModeMap
+			}
+		}
+	}
+
+	if s == nil || s.Schema == nil {
+		return NestingModeSingle
+	}
+
+	if s.Schema.Type != nil && len(s.Schema.Type) > 0 {
+		switch s.Schema.Type[0] {
+		case "array":
+			if s.Schema.UniqueItems != nil && *s.Schema.UniqueItems {
+				return NestingModeSet
+			}
+
+			return NestingModeList
+		case "object":
+			if s.Schema.AdditionalProperties != nil {
+				return NestingModeMap
+			}
+
+			return NestingModeSingle
+		}
+	}
+
+	return NestingModeSingle
+}
@@ -0,0 +1,72 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oas_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-codegen-openapi/internal/mapper/oas"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDetermineNestingMode(t *testing.T) {
+	t.Parallel()
+
+	trueVal := true
+
+	testCases := map[string]struct {
+		schema   *base.Schema
+		expected oas.NestingMode
+	}{
+		"object": {
+			schema:   &base.Schema{Type: []string{"object"}},
+			expected: oas.NestingModeSingle,
+		},
+		"object with additionalProperties": {
+			schema: &base.Schema{
+				Type:                 []string{"object"},
+				AdditionalProperties: base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+			},
+			expected: oas.NestingModeMap,
+		},
+		"array": {
+			schema:   &base.Schema{Type: []string{"array"}},
+			expected: oas.NestingModeList,
+		},
+		"array with uniqueItems": {
+			schema: &base.Schema{
+				Type:        []string{"array"},
+				UniqueItems: &trueVal,
+			},
+			expected: oas.NestingModeSet,
+		},
+		"extension override": {
+			schema: &base.Schema{
+				Type: []string{"array"},
+				Extensions: func() *orderedmap.Map[string, *yaml.Node] {
+					extensions := orderedmap.New[string, *yaml.Node]()
+					extensions.Set("x-terraform-nesting-mode", &yaml.Node{Value: "set"})
+					return extensions
+				}(),
+			},
+			expected: oas.NestingModeSet,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := &oas.OASSchema{Schema: testCase.schema}
+
+			if got := oas.DetermineNestingMode(s); got != testCase.expected {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
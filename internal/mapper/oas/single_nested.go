@@ -9,36 +9,137 @@ import (
 	"github.com/hashicorp/terraform-plugin-codegen-spec/datasource"
 	"github.com/hashicorp/terraform-plugin-codegen-spec/resource"
 	"github.com/hashicorp/terraform-plugin-codegen-spec/schema"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
 )
 
-func (s *OASSchema) BuildSingleNestedResource(name string, computability schema.ComputedOptionalRequired) (*resource.Attribute, error) {
-	objectAttributes, err := s.BuildResourceAttributes()
+// extAdditionalPropertiesName is the OAS extension providers can set on an
+// object schema to control the name of the sibling attribute generated for
+// that schema's `additionalProperties`, when the schema also declares fixed
+// `properties`. Defaults to defaultAdditionalPropertiesName when unset.
+const extAdditionalPropertiesName = "x-terraform-additional-properties-name"
+
+const defaultAdditionalPropertiesName = "additional_properties"
+
+// BuildSingleNestedResource builds a SingleNested resource.Attribute for a
+// plain nested object (NestingModeSingle). Callers - in practice only the
+// attribute dispatcher in attributes.go - must use DetermineNestingMode to
+// route array and additionalProperties-only shapes to the List/Set/Map
+// builders instead. registry may be nil, in which case OAS constraints fall
+// back to the generator's built-in plan modifier/validator translation.
+func (s *OASSchema) BuildSingleNestedResource(name string, computability schema.ComputedOptionalRequired, registry *ConstraintRegistry) (*resource.Attribute, error) {
+	objectAttributes, err := s.BuildResourceAttributes(registry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build nested object schema proxy - %w", err)
 	}
 
+	if s.HasAdditionalPropertiesHybrid() {
+		additionalPropertiesAttribute, err := s.buildAdditionalPropertiesResourceAttribute(registry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build additional properties attribute - %w", err)
+		}
+
+		*objectAttributes = append(*objectAttributes, *additionalPropertiesAttribute)
+	}
+
 	return &resource.Attribute{
 		Name: name,
 		SingleNested: &resource.SingleNestedAttribute{
 			Attributes:               *objectAttributes,
 			ComputedOptionalRequired: computability,
 			Description:              s.GetDescription(),
+			PlanModifiers:            s.buildObjectPlanModifiers(),
+			Validators:               s.buildObjectSizeValidators(registry),
 		},
 	}, nil
 }
 
-func (s *OASSchema) BuildSingleNestedDataSource(name string, computability schema.ComputedOptionalRequired) (*datasource.Attribute, error) {
-	objectAttributes, err := s.BuildDataSourceAttributes()
+// BuildSingleNestedDataSource builds a SingleNested datasource.Attribute. See
+// BuildSingleNestedResource for the registry contract. Data sources have no
+// plan, so only Validators are populated from constraints.
+func (s *OASSchema) BuildSingleNestedDataSource(name string, computability schema.ComputedOptionalRequired, registry *ConstraintRegistry) (*datasource.Attribute, error) {
+	objectAttributes, err := s.BuildDataSourceAttributes(registry)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build nested object schema proxy - %w", err)
 	}
 
+	if s.HasAdditionalPropertiesHybrid() {
+		additionalPropertiesAttribute, err := s.buildAdditionalPropertiesDataSourceAttribute(registry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build additional properties attribute - %w", err)
+		}
+
+		*objectAttributes = append(*objectAttributes, *additionalPropertiesAttribute)
+	}
+
 	return &datasource.Attribute{
 		Name: name,
 		SingleNested: &datasource.SingleNestedAttribute{
 			Attributes:               *objectAttributes,
 			ComputedOptionalRequired: computability,
 			Description:              s.GetDescription(),
+			Validators:               s.buildObjectSizeValidators(registry),
 		},
 	}, nil
 }
+
+// HasAdditionalPropertiesHybrid returns true when the schema declares both
+// fixed `properties` and an `additionalProperties` schema, meaning it needs
+// to be emitted as a SingleNested attribute for the fixed fields plus a
+// sibling Map/MapNested attribute for the arbitrary ones.
+func (s *OASSchema) HasAdditionalPropertiesHybrid() bool {
+	if s.Schema == nil || s.Schema.AdditionalProperties == nil {
+		return false
+	}
+
+	return len(s.Schema.Properties) > 0
+}
+
+// GetAdditionalPropertiesName returns the attribute name to use for the
+// sibling Map/MapNested attribute generated from `additionalProperties`,
+// honoring the extAdditionalPropertiesName extension when present.
+func (s *OASSchema) GetAdditionalPropertiesName() string {
+	if s.Schema == nil || s.Schema.Extensions == nil {
+		return defaultAdditionalPropertiesName
+	}
+
+	extension, ok := s.Schema.Extensions.Get(extAdditionalPropertiesName)
+	if !ok || extension == nil || extension.Value == "" {
+		return defaultAdditionalPropertiesName
+	}
+
+	return extension.Value
+}
+
+// buildAdditionalPropertiesSchemaProxy wraps the schema's `additionalProperties`
+// in a synthetic object property so the existing map-building logic in
+// BuildResourceAttributes/BuildDataSourceAttributes can be reused verbatim.
+func (s *OASSchema) buildAdditionalPropertiesSchemaProxy() *base.Schema {
+	return &base.Schema{
+		Type: []string{"object"},
+		Properties: map[string]*base.SchemaProxy{
+			s.GetAdditionalPropertiesName(): base.CreateSchemaProxy(&base.Schema{
+				Type:                 []string{"object"},
+				Description:          s.GetDescription(),
+				AdditionalProperties: s.Schema.AdditionalProperties,
+			}),
+		},
+	}
+}
+
+func (s *OASSchema) buildAdditionalPropertiesResourceAttribute(registry *ConstraintRegistry) (*resource.Attribute, error) {
+	wrapperAttributes, err := (&OASSchema{Schema: s.buildAdditionalPropertiesSchemaProxy()}).BuildResourceAttributes(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &(*wrapperAttributes)[0], nil
+}
+
+func (s *OASSchema) buildAdditionalPropertiesDataSourceAttribute(registry *ConstraintRegistry) (*datasource.Attribute, error) {
+	wrapperAttributes, err := (&OASSchema{Schema: s.buildAdditionalPropertiesSchemaProxy()}).BuildDataSourceAttributes(registry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &(*wrapperAttributes)[0], nil
+}
@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package oas_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/hashicorp/terraform-plugin-codegen-openapi/internal/mapper/oas"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/datasource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/resource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/schema"
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildSingleNestedResource_additionalPropertiesHybrid(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		schema             *base.Schema
+		expectedAttributes []resource.Attribute
+	}{
+		"fixed properties plus additionalProperties, default sibling name": {
+			schema: &base.Schema{
+				Type:     []string{"object"},
+				Required: []string{"name"},
+				Properties: map[string]*base.SchemaProxy{
+					"name": base.CreateSchemaProxy(&base.Schema{
+						Type:        []string{"string"},
+						Description: "hey there! I'm a required name string.",
+					}),
+				},
+				AdditionalProperties: base.CreateSchemaProxy(&base.Schema{
+					Type: []string{"string"},
+				}),
+			},
+			expectedAttributes: []resource.Attribute{
+				{
+					Name: "name",
+					String: &resource.StringAttribute{
+						ComputedOptionalRequired: schema.Required,
+						Description:              pointer("hey there! I'm a required name string."),
+					},
+				},
+				{
+					Name: "additional_properties",
+					Map: &resource.MapAttribute{
+						ElementType: schema.ElementType{
+							String: &schema.StringType{},
+						},
+						ComputedOptionalRequired: schema.ComputedOptional,
+					},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := oas.OASSchema{Schema: testCase.schema}
+
+			attribute, err := s.BuildSingleNestedResource("nested_attr", schema.ComputedOptional, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(attribute.SingleNested.Attributes, testCase.expectedAttributes); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+// TestBuildResourceAttributes_additionalPropertiesHybridDispatch proves that
+// a schema with both fixed `properties` and `additionalProperties` reaches
+// HasAdditionalPropertiesHybrid and BuildSingleNestedResource from the real
+// attribute dispatcher, not just from direct calls in this file's other
+// tests: the dispatcher must not instead treat the property as a plain Map,
+// which would silently drop its fixed fields.
+func TestBuildResourceAttributes_additionalPropertiesHybridDispatch(t *testing.T) {
+	t.Parallel()
+
+	s := oas.OASSchema{
+		Schema: &base.Schema{
+			Type: []string{"object"},
+			Properties: map[string]*base.SchemaProxy{
+				"config": base.CreateSchemaProxy(&base.Schema{
+					Type:     []string{"object"},
+					Required: []string{"name"},
+					Properties: map[string]*base.SchemaProxy{
+						"name": base.CreateSchemaProxy(&base.Schema{Type: []string{"string"}}),
+					},
+					AdditionalProperties: base.CreateSchemaProxy(&base.Schema{
+						Type: []string{"string"},
+					}),
+				}),
+			},
+		},
+	}
+
+	attributes, err := s.BuildResourceAttributes(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(*attributes) != 1 || (*attributes)[0].SingleNested == nil {
+		t.Fatalf("expected dispatcher to route the hybrid schema to SingleNested, got %+v", *attributes)
+	}
+
+	expected := []resource.Attribute{
+		{
+			Name:   "name",
+			String: &resource.StringAttribute{ComputedOptionalRequired: schema.Required},
+		},
+		{
+			Name: "additional_properties",
+			Map: &resource.MapAttribute{
+				ElementType:              schema.ElementType{String: &schema.StringType{}},
+				ComputedOptionalRequired: schema.ComputedOptional,
+			},
+		},
+	}
+
+	if diff := cmp.Diff((*attributes)[0].SingleNested.Attributes, expected); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestBuildSingleNestedDataSource_additionalPropertiesHybrid(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		schema             *base.Schema
+		expectedAttributes []datasource.Attribute
+	}{
+		"fixed properties plus additionalProperties, custom sibling name": {
+			schema: &base.Schema{
+				Type: []string{"object"},
+				Properties: map[string]*base.SchemaProxy{
+					"type": base.CreateSchemaProxy(&base.Schema{
+						Type: []string{"string"},
+					}),
+				},
+				AdditionalProperties: base.CreateSchemaProxy(&base.Schema{
+					Type: []string{"string"},
+				}),
+				Extensions: func() *orderedmap.Map[string, *yaml.Node] {
+					extensions := orderedmap.New[string, *yaml.Node]()
+					extensions.Set("x-terraform-additional-properties-name", &yaml.Node{Value: "extra_labels"})
+					return extensions
+				}(),
+			},
+			expectedAttributes: []datasource.Attribute{
+				{
+					Name: "type",
+					String: &datasource.StringAttribute{
+						ComputedOptionalRequired: schema.ComputedOptional,
+					},
+				},
+				{
+					Name: "extra_labels",
+					Map: &datasource.MapAttribute{
+						ElementType: schema.ElementType{
+							String: &schema.StringType{},
+						},
+						ComputedOptionalRequired: schema.ComputedOptional,
+					},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			s := oas.OASSchema{Schema: testCase.schema}
+
+			attribute, err := s.BuildSingleNestedDataSource("nested_attr", schema.ComputedOptional, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(attribute.SingleNested.Attributes, testCase.expectedAttributes); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
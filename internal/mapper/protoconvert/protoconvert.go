@@ -0,0 +1,595 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+// Package protoconvert converts between the resource/datasource attribute
+// trees produced by internal/mapper/oas and Terraform protocol v6 schema
+// messages, so provider authors can diff a generated schema against a live
+// provider's schema in CI. Both resource schemas (ToSchemaV6/FromSchemaV6)
+// and data source schemas (ToDataSourceSchemaV6/FromDataSourceSchemaV6) are
+// supported, since a live provider always exposes both.
+//
+// Every nested attribute produced by this mapper (SingleNested, ListNested,
+// SetNested, MapNested) is emitted as an attribute with NestedType populated,
+// never as a legacy SchemaNestedBlock - this package's SchemaBlock.BlockTypes
+// is therefore always empty, mirroring the "attributes, not blocks" shape
+// the rest of this codegen module produces.
+package protoconvert
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-codegen-spec/datasource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/resource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ToSchemaV6 converts a mapper-produced resource attribute tree into a
+// protocol v6 SchemaBlock.
+func ToSchemaV6(attributes []resource.Attribute) (*tfprotov6.SchemaBlock, error) {
+	converted := make([]*tfprotov6.SchemaAttribute, 0, len(attributes))
+
+	for _, attribute := range attributes {
+		protoAttribute, err := attributeToV6(attribute)
+		if err != nil {
+			return nil, err
+		}
+
+		converted = append(converted, protoAttribute)
+	}
+
+	return &tfprotov6.SchemaBlock{Attributes: converted}, nil
+}
+
+// FromSchemaV6 is the inverse of ToSchemaV6: it reconstructs a
+// resource.Attribute tree from a live provider's protocol v6 SchemaBlock so
+// it can be diffed against the generator's own output. Block-style nesting
+// (SchemaBlock.BlockTypes) has no equivalent in this mapper's attribute-only
+// IR and is reported as an error rather than silently dropped.
+func FromSchemaV6(block *tfprotov6.SchemaBlock) ([]resource.Attribute, error) {
+	if len(block.BlockTypes) > 0 {
+		return nil, fmt.Errorf("schema uses legacy block syntax (%d block types), which this mapper's attribute-only IR cannot represent", len(block.BlockTypes))
+	}
+
+	attributes := make([]resource.Attribute, 0, len(block.Attributes))
+
+	for _, protoAttribute := range block.Attributes {
+		attribute, err := attributeFromV6(protoAttribute)
+		if err != nil {
+			return nil, err
+		}
+
+		attributes = append(attributes, *attribute)
+	}
+
+	return attributes, nil
+}
+
+// ToDataSourceSchemaV6 mirrors ToSchemaV6 for a data source attribute tree.
+func ToDataSourceSchemaV6(attributes []datasource.Attribute) (*tfprotov6.SchemaBlock, error) {
+	converted, err := attributesToV6DataSource(attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.SchemaBlock{Attributes: converted}, nil
+}
+
+// FromDataSourceSchemaV6 mirrors FromSchemaV6 for a data source attribute
+// tree.
+func FromDataSourceSchemaV6(block *tfprotov6.SchemaBlock) ([]datasource.Attribute, error) {
+	if len(block.BlockTypes) > 0 {
+		return nil, fmt.Errorf("schema uses legacy block syntax (%d block types), which this mapper's attribute-only IR cannot represent", len(block.BlockTypes))
+	}
+
+	attributes := make([]datasource.Attribute, 0, len(block.Attributes))
+
+	for _, protoAttribute := range block.Attributes {
+		attribute, err := attributeFromV6DataSource(protoAttribute)
+		if err != nil {
+			return nil, err
+		}
+
+		attributes = append(attributes, *attribute)
+	}
+
+	return attributes, nil
+}
+
+func attributeToV6(attribute resource.Attribute) (*tfprotov6.SchemaAttribute, error) {
+	cor := attributeComputedOptionalRequired(attribute)
+
+	protoAttribute := &tfprotov6.SchemaAttribute{
+		Name:      attribute.Name,
+		Required:  cor == schema.Required,
+		Optional:  cor == schema.Optional || cor == schema.ComputedOptional,
+		Computed:  cor == schema.Computed || cor == schema.ComputedOptional,
+		Sensitive: attributeIsSensitive(attribute),
+	}
+
+	// minItems mirrors cor into the NestedType: Terraform core requires a
+	// NestedType attribute with MinItems >= 1 regardless of what Optional/
+	// Computed/Required say, so a Required nested attribute must carry
+	// MinItems: 1 for that requirement to actually be enforced.
+	minItems := int64(0)
+	if cor == schema.Required {
+		minItems = 1
+	}
+
+	switch {
+	case attribute.String != nil:
+		protoAttribute.Type = tftypes.String
+	case attribute.Bool != nil:
+		protoAttribute.Type = tftypes.Bool
+	case attribute.Int64 != nil, attribute.Float64 != nil, attribute.Number != nil:
+		protoAttribute.Type = tftypes.Number
+	case attribute.Dynamic != nil:
+		protoAttribute.Type = tftypes.DynamicPseudoType
+	case attribute.List != nil:
+		protoAttribute.Type = tftypes.List{ElementType: elementTypeToV6(attribute.List.ElementType)}
+	case attribute.Set != nil:
+		protoAttribute.Type = tftypes.Set{ElementType: elementTypeToV6(attribute.Set.ElementType)}
+	case attribute.Map != nil:
+		protoAttribute.Type = tftypes.Map{ElementType: elementTypeToV6(attribute.Map.ElementType)}
+	case attribute.SingleNested != nil:
+		nestedAttributes, err := attributesToV6(attribute.SingleNested.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		protoAttribute.NestedType = &tfprotov6.SchemaObject{
+			Attributes: nestedAttributes,
+			Nesting:    tfprotov6.SchemaObjectNestingModeSingle,
+			MinItems:   minItems,
+		}
+	case attribute.ListNested != nil:
+		nestedAttributes, err := attributesToV6(attribute.ListNested.NestedObject.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		protoAttribute.NestedType = &tfprotov6.SchemaObject{
+			Attributes: nestedAttributes,
+			Nesting:    tfprotov6.SchemaObjectNestingModeList,
+			MinItems:   minItems,
+		}
+	case attribute.SetNested != nil:
+		nestedAttributes, err := attributesToV6(attribute.SetNested.NestedObject.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		protoAttribute.NestedType = &tfprotov6.SchemaObject{
+			Attributes: nestedAttributes,
+			Nesting:    tfprotov6.SchemaObjectNestingModeSet,
+			MinItems:   minItems,
+		}
+	case attribute.MapNested != nil:
+		nestedAttributes, err := attributesToV6(attribute.MapNested.NestedObject.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		protoAttribute.NestedType = &tfprotov6.SchemaObject{
+			Attributes: nestedAttributes,
+			Nesting:    tfprotov6.SchemaObjectNestingModeMap,
+			MinItems:   minItems,
+		}
+	default:
+		return nil, fmt.Errorf("attribute %q has no recognized type set", attribute.Name)
+	}
+
+	// Mirrors the Terraform core conversion: a NestedType with MinItems >= 1
+	// is Required regardless of the generator's own ComputedOptionalRequired,
+	// and Type/NestedType stay mutually exclusive.
+	if protoAttribute.NestedType != nil && protoAttribute.NestedType.MinItems >= 1 {
+		protoAttribute.Required = true
+		protoAttribute.Optional = false
+	}
+
+	return protoAttribute, nil
+}
+
+func attributesToV6(attributes []resource.Attribute) ([]*tfprotov6.SchemaAttribute, error) {
+	converted := make([]*tfprotov6.SchemaAttribute, 0, len(attributes))
+
+	for _, attribute := range attributes {
+		protoAttribute, err := attributeToV6(attribute)
+		if err != nil {
+			return nil, err
+		}
+
+		converted = append(converted, protoAttribute)
+	}
+
+	return converted, nil
+}
+
+// attributeToV6DataSource mirrors attributeToV6 for datasource.Attribute.
+// Data sources have no writeOnly/plan concept, but otherwise convert
+// identically - including the Required/MinItems mirroring for nested
+// attributes.
+func attributeToV6DataSource(attribute datasource.Attribute) (*tfprotov6.SchemaAttribute, error) {
+	cor := attributeComputedOptionalRequired(attribute)
+
+	protoAttribute := &tfprotov6.SchemaAttribute{
+		Name:      attribute.Name,
+		Required:  cor == schema.Required,
+		Optional:  cor == schema.Optional || cor == schema.ComputedOptional,
+		Computed:  cor == schema.Computed || cor == schema.ComputedOptional,
+		Sensitive: attributeIsSensitive(attribute),
+	}
+
+	minItems := int64(0)
+	if cor == schema.Required {
+		minItems = 1
+	}
+
+	switch {
+	case attribute.String != nil:
+		protoAttribute.Type = tftypes.String
+	case attribute.Bool != nil:
+		protoAttribute.Type = tftypes.Bool
+	case attribute.Int64 != nil, attribute.Float64 != nil, attribute.Number != nil:
+		protoAttribute.Type = tftypes.Number
+	case attribute.Dynamic != nil:
+		protoAttribute.Type = tftypes.DynamicPseudoType
+	case attribute.List != nil:
+		protoAttribute.Type = tftypes.List{ElementType: elementTypeToV6(attribute.List.ElementType)}
+	case attribute.Set != nil:
+		protoAttribute.Type = tftypes.Set{ElementType: elementTypeToV6(attribute.Set.ElementType)}
+	case attribute.Map != nil:
+		protoAttribute.Type = tftypes.Map{ElementType: elementTypeToV6(attribute.Map.ElementType)}
+	case attribute.SingleNested != nil:
+		nestedAttributes, err := attributesToV6DataSource(attribute.SingleNested.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		protoAttribute.NestedType = &tfprotov6.SchemaObject{
+			Attributes: nestedAttributes,
+			Nesting:    tfprotov6.SchemaObjectNestingModeSingle,
+			MinItems:   minItems,
+		}
+	case attribute.ListNested != nil:
+		nestedAttributes, err := attributesToV6DataSource(attribute.ListNested.NestedObject.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		protoAttribute.NestedType = &tfprotov6.SchemaObject{
+			Attributes: nestedAttributes,
+			Nesting:    tfprotov6.SchemaObjectNestingModeList,
+			MinItems:   minItems,
+		}
+	case attribute.SetNested != nil:
+		nestedAttributes, err := attributesToV6DataSource(attribute.SetNested.NestedObject.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		protoAttribute.NestedType = &tfprotov6.SchemaObject{
+			Attributes: nestedAttributes,
+			Nesting:    tfprotov6.SchemaObjectNestingModeSet,
+			MinItems:   minItems,
+		}
+	case attribute.MapNested != nil:
+		nestedAttributes, err := attributesToV6DataSource(attribute.MapNested.NestedObject.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		protoAttribute.NestedType = &tfprotov6.SchemaObject{
+			Attributes: nestedAttributes,
+			Nesting:    tfprotov6.SchemaObjectNestingModeMap,
+			MinItems:   minItems,
+		}
+	default:
+		return nil, fmt.Errorf("attribute %q has no recognized type set", attribute.Name)
+	}
+
+	if protoAttribute.NestedType != nil && protoAttribute.NestedType.MinItems >= 1 {
+		protoAttribute.Required = true
+		protoAttribute.Optional = false
+	}
+
+	return protoAttribute, nil
+}
+
+func attributesToV6DataSource(attributes []datasource.Attribute) ([]*tfprotov6.SchemaAttribute, error) {
+	converted := make([]*tfprotov6.SchemaAttribute, 0, len(attributes))
+
+	for _, attribute := range attributes {
+		protoAttribute, err := attributeToV6DataSource(attribute)
+		if err != nil {
+			return nil, err
+		}
+
+		converted = append(converted, protoAttribute)
+	}
+
+	return converted, nil
+}
+
+func attributeFromV6(protoAttribute *tfprotov6.SchemaAttribute) (*resource.Attribute, error) {
+	attribute := &resource.Attribute{Name: protoAttribute.Name}
+
+	cor := schema.ComputedOptionalRequired("")
+
+	switch {
+	case protoAttribute.Required:
+		cor = schema.Required
+	case protoAttribute.Optional && protoAttribute.Computed:
+		cor = schema.ComputedOptional
+	case protoAttribute.Optional:
+		cor = schema.Optional
+	case protoAttribute.Computed:
+		cor = schema.Computed
+	}
+
+	if protoAttribute.NestedType != nil {
+		nestedAttributes, err := attributesFromV6(protoAttribute.NestedType.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		switch protoAttribute.NestedType.Nesting {
+		case tfprotov6.SchemaObjectNestingModeSingle:
+			attribute.SingleNested = &resource.SingleNestedAttribute{
+				Attributes:               nestedAttributes,
+				ComputedOptionalRequired: cor,
+			}
+		case tfprotov6.SchemaObjectNestingModeList:
+			attribute.ListNested = &resource.ListNestedAttribute{
+				NestedObject:             resource.NestedAttributeObject{Attributes: nestedAttributes},
+				ComputedOptionalRequired: cor,
+			}
+		case tfprotov6.SchemaObjectNestingModeSet:
+			attribute.SetNested = &resource.SetNestedAttribute{
+				NestedObject:             resource.NestedAttributeObject{Attributes: nestedAttributes},
+				ComputedOptionalRequired: cor,
+			}
+		case tfprotov6.SchemaObjectNestingModeMap:
+			attribute.MapNested = &resource.MapNestedAttribute{
+				NestedObject:             resource.NestedAttributeObject{Attributes: nestedAttributes},
+				ComputedOptionalRequired: cor,
+			}
+		default:
+			return nil, fmt.Errorf("attribute %q uses unsupported nesting mode %v", protoAttribute.Name, protoAttribute.NestedType.Nesting)
+		}
+
+		return attribute, nil
+	}
+
+	switch {
+	case protoAttribute.Type.Is(tftypes.String):
+		var sensitive *bool
+		if protoAttribute.Sensitive {
+			sensitive = pointerTo(true)
+		}
+
+		attribute.String = &resource.StringAttribute{ComputedOptionalRequired: cor, Sensitive: sensitive}
+	case protoAttribute.Type.Is(tftypes.Bool):
+		attribute.Bool = &resource.BoolAttribute{ComputedOptionalRequired: cor}
+	case protoAttribute.Type.Is(tftypes.Number):
+		attribute.Number = &resource.NumberAttribute{ComputedOptionalRequired: cor}
+	case protoAttribute.Type.Is(tftypes.DynamicPseudoType):
+		attribute.Dynamic = &resource.DynamicAttribute{ComputedOptionalRequired: cor}
+	case protoAttribute.Type.Is(tftypes.List{}):
+		attribute.List = &resource.ListAttribute{ComputedOptionalRequired: cor, ElementType: elementTypeFromV6(protoAttribute.Type.(tftypes.List).ElementType)}
+	case protoAttribute.Type.Is(tftypes.Set{}):
+		attribute.Set = &resource.SetAttribute{ComputedOptionalRequired: cor, ElementType: elementTypeFromV6(protoAttribute.Type.(tftypes.Set).ElementType)}
+	case protoAttribute.Type.Is(tftypes.Map{}):
+		attribute.Map = &resource.MapAttribute{ComputedOptionalRequired: cor, ElementType: elementTypeFromV6(protoAttribute.Type.(tftypes.Map).ElementType)}
+	default:
+		return nil, fmt.Errorf("attribute %q has unsupported type %s", protoAttribute.Name, protoAttribute.Type)
+	}
+
+	return attribute, nil
+}
+
+func attributesFromV6(protoAttributes []*tfprotov6.SchemaAttribute) ([]resource.Attribute, error) {
+	attributes := make([]resource.Attribute, 0, len(protoAttributes))
+
+	for _, protoAttribute := range protoAttributes {
+		attribute, err := attributeFromV6(protoAttribute)
+		if err != nil {
+			return nil, err
+		}
+
+		attributes = append(attributes, *attribute)
+	}
+
+	return attributes, nil
+}
+
+// attributeFromV6DataSource mirrors attributeFromV6 for datasource.Attribute.
+func attributeFromV6DataSource(protoAttribute *tfprotov6.SchemaAttribute) (*datasource.Attribute, error) {
+	attribute := &datasource.Attribute{Name: protoAttribute.Name}
+
+	cor := schema.ComputedOptionalRequired("")
+
+	switch {
+	case protoAttribute.Required:
+		cor = schema.Required
+	case protoAttribute.Optional && protoAttribute.Computed:
+		cor = schema.ComputedOptional
+	case protoAttribute.Optional:
+		cor = schema.Optional
+	case protoAttribute.Computed:
+		cor = schema.Computed
+	}
+
+	if protoAttribute.NestedType != nil {
+		nestedAttributes, err := attributesFromV6DataSource(protoAttribute.NestedType.Attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		switch protoAttribute.NestedType.Nesting {
+		case tfprotov6.SchemaObjectNestingModeSingle:
+			attribute.SingleNested = &datasource.SingleNestedAttribute{
+				Attributes:               nestedAttributes,
+				ComputedOptionalRequired: cor,
+			}
+		case tfprotov6.SchemaObjectNestingModeList:
+			attribute.ListNested = &datasource.ListNestedAttribute{
+				NestedObject:             datasource.NestedAttributeObject{Attributes: nestedAttributes},
+				ComputedOptionalRequired: cor,
+			}
+		case tfprotov6.SchemaObjectNestingModeSet:
+			attribute.SetNested = &datasource.SetNestedAttribute{
+				NestedObject:             datasource.NestedAttributeObject{Attributes: nestedAttributes},
+				ComputedOptionalRequired: cor,
+			}
+		case tfprotov6.SchemaObjectNestingModeMap:
+			attribute.MapNested = &datasource.MapNestedAttribute{
+				NestedObject:             datasource.NestedAttributeObject{Attributes: nestedAttributes},
+				ComputedOptionalRequired: cor,
+			}
+		default:
+			return nil, fmt.Errorf("attribute %q uses unsupported nesting mode %v", protoAttribute.Name, protoAttribute.NestedType.Nesting)
+		}
+
+		return attribute, nil
+	}
+
+	switch {
+	case protoAttribute.Type.Is(tftypes.String):
+		var sensitive *bool
+		if protoAttribute.Sensitive {
+			sensitive = pointerTo(true)
+		}
+
+		attribute.String = &datasource.StringAttribute{ComputedOptionalRequired: cor, Sensitive: sensitive}
+	case protoAttribute.Type.Is(tftypes.Bool):
+		attribute.Bool = &datasource.BoolAttribute{ComputedOptionalRequired: cor}
+	case protoAttribute.Type.Is(tftypes.Number):
+		attribute.Number = &datasource.NumberAttribute{ComputedOptionalRequired: cor}
+	case protoAttribute.Type.Is(tftypes.DynamicPseudoType):
+		attribute.Dynamic = &datasource.DynamicAttribute{ComputedOptionalRequired: cor}
+	case protoAttribute.Type.Is(tftypes.List{}):
+		attribute.List = &datasource.ListAttribute{ComputedOptionalRequired: cor, ElementType: elementTypeFromV6(protoAttribute.Type.(tftypes.List).ElementType)}
+	case protoAttribute.Type.Is(tftypes.Set{}):
+		attribute.Set = &datasource.SetAttribute{ComputedOptionalRequired: cor, ElementType: elementTypeFromV6(protoAttribute.Type.(tftypes.Set).ElementType)}
+	case protoAttribute.Type.Is(tftypes.Map{}):
+		attribute.Map = &datasource.MapAttribute{ComputedOptionalRequired: cor, ElementType: elementTypeFromV6(protoAttribute.Type.(tftypes.Map).ElementType)}
+	default:
+		return nil, fmt.Errorf("attribute %q has unsupported type %s", protoAttribute.Name, protoAttribute.Type)
+	}
+
+	return attribute, nil
+}
+
+func attributesFromV6DataSource(protoAttributes []*tfprotov6.SchemaAttribute) ([]datasource.Attribute, error) {
+	attributes := make([]datasource.Attribute, 0, len(protoAttributes))
+
+	for _, protoAttribute := range protoAttributes {
+		attribute, err := attributeFromV6DataSource(protoAttribute)
+		if err != nil {
+			return nil, err
+		}
+
+		attributes = append(attributes, *attribute)
+	}
+
+	return attributes, nil
+}
+
+func elementTypeToV6(elementType schema.ElementType) tftypes.Type {
+	switch {
+	case elementType.String != nil:
+		return tftypes.String
+	case elementType.Bool != nil:
+		return tftypes.Bool
+	case elementType.Int64 != nil, elementType.Float64 != nil, elementType.Number != nil:
+		return tftypes.Number
+	default:
+		return tftypes.DynamicPseudoType
+	}
+}
+
+func elementTypeFromV6(t tftypes.Type) schema.ElementType {
+	switch {
+	case t.Is(tftypes.String):
+		return schema.ElementType{String: &schema.StringType{}}
+	case t.Is(tftypes.Bool):
+		return schema.ElementType{Bool: &schema.BoolType{}}
+	case t.Is(tftypes.Number):
+		return schema.ElementType{Number: &schema.NumberType{}}
+	default:
+		return schema.ElementType{Dynamic: &schema.DynamicType{}}
+	}
+}
+
+// attributeComputedOptionalRequired reads ComputedOptionalRequired out of
+// whichever typed field (String, Int64, SingleNested, ...) a resource or
+// datasource Attribute has populated, so the resource and data source
+// conversion paths can share one implementation.
+func attributeComputedOptionalRequired(attribute any) schema.ComputedOptionalRequired {
+	tag := attributeTypedFieldName(attribute)
+	if tag == "" {
+		return ""
+	}
+
+	typedField := reflect.ValueOf(attribute).FieldByName(tag)
+	if typedField.IsNil() {
+		return ""
+	}
+
+	corField := typedField.Elem().FieldByName("ComputedOptionalRequired")
+	if !corField.IsValid() {
+		return ""
+	}
+
+	cor, _ := corField.Interface().(schema.ComputedOptionalRequired)
+
+	return cor
+}
+
+// attributeIsSensitive reads the *bool Sensitive field out of whichever typed
+// field a resource or datasource Attribute has populated, defaulting to false
+// when the attribute kind has no such field (e.g. nested attributes).
+func attributeIsSensitive(attribute any) bool {
+	tag := attributeTypedFieldName(attribute)
+	if tag == "" {
+		return false
+	}
+
+	typedField := reflect.ValueOf(attribute).FieldByName(tag)
+	if typedField.IsNil() {
+		return false
+	}
+
+	sensitiveField := typedField.Elem().FieldByName("Sensitive")
+	if !sensitiveField.IsValid() || sensitiveField.IsNil() {
+		return false
+	}
+
+	return sensitiveField.Elem().Bool()
+}
+
+func attributeTypedFieldName(attribute any) string {
+	value := reflect.ValueOf(attribute)
+	valueType := value.Type()
+
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if field.Name == "Name" {
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		if fieldValue.Kind() == reflect.Ptr && !fieldValue.IsNil() {
+			return field.Name
+		}
+	}
+
+	return ""
+}
+
+func pointerTo[T any](v T) *T {
+	return &v
+}
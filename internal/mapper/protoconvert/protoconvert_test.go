@@ -0,0 +1,332 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+package protoconvert_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-codegen-spec/datasource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/resource"
+	"github.com/hashicorp/terraform-plugin-codegen-spec/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+
+	"github.com/hashicorp/terraform-plugin-codegen-openapi/internal/mapper/protoconvert"
+)
+
+func TestToSchemaV6_nestingModes(t *testing.T) {
+	t.Parallel()
+
+	leaf := []resource.Attribute{
+		{
+			Name:   "name",
+			String: &resource.StringAttribute{ComputedOptionalRequired: schema.Required},
+		},
+	}
+
+	testCases := map[string]struct {
+		attribute    resource.Attribute
+		expectedMode tfprotov6.SchemaObjectNestingMode
+	}{
+		"single nested": {
+			attribute: resource.Attribute{
+				Name:         "single_attr",
+				SingleNested: &resource.SingleNestedAttribute{Attributes: leaf, ComputedOptionalRequired: schema.Computed},
+			},
+			expectedMode: tfprotov6.SchemaObjectNestingModeSingle,
+		},
+		"list nested": {
+			attribute: resource.Attribute{
+				Name: "list_attr",
+				ListNested: &resource.ListNestedAttribute{
+					NestedObject:             resource.NestedAttributeObject{Attributes: leaf},
+					ComputedOptionalRequired: schema.Computed,
+				},
+			},
+			expectedMode: tfprotov6.SchemaObjectNestingModeList,
+		},
+		"set nested": {
+			attribute: resource.Attribute{
+				Name: "set_attr",
+				SetNested: &resource.SetNestedAttribute{
+					NestedObject:             resource.NestedAttributeObject{Attributes: leaf},
+					ComputedOptionalRequired: schema.Computed,
+				},
+			},
+			expectedMode: tfprotov6.SchemaObjectNestingModeSet,
+		},
+		"map nested": {
+			attribute: resource.Attribute{
+				Name: "map_attr",
+				MapNested: &resource.MapNestedAttribute{
+					NestedObject:             resource.NestedAttributeObject{Attributes: leaf},
+					ComputedOptionalRequired: schema.Computed,
+				},
+			},
+			expectedMode: tfprotov6.SchemaObjectNestingModeMap,
+		},
+	}
+
+	for name, testCase := range testCases {
+		name, testCase := name, testCase
+
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			block, err := protoconvert.ToSchemaV6([]resource.Attribute{testCase.attribute})
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if len(block.Attributes) != 1 {
+				t.Fatalf("expected 1 converted attribute, got %d", len(block.Attributes))
+			}
+
+			protoAttribute := block.Attributes[0]
+
+			if protoAttribute.NestedType == nil {
+				t.Fatalf("expected NestedType to be populated for %s", name)
+			}
+
+			if protoAttribute.Type != nil {
+				t.Errorf("expected Type to be unset when NestedType is populated, got %v", protoAttribute.Type)
+			}
+
+			if protoAttribute.NestedType.Nesting != testCase.expectedMode {
+				t.Errorf("expected nesting mode %v, got %v", testCase.expectedMode, protoAttribute.NestedType.Nesting)
+			}
+
+			if len(protoAttribute.NestedType.Attributes) != 1 || protoAttribute.NestedType.Attributes[0].Name != "name" {
+				t.Errorf("expected nested attribute %q to carry through, got %+v", "name", protoAttribute.NestedType.Attributes)
+			}
+		})
+	}
+}
+
+func TestToSchemaV6_attributesNotBlocks(t *testing.T) {
+	t.Parallel()
+
+	attributes := []resource.Attribute{
+		{
+			Name: "single_attr",
+			SingleNested: &resource.SingleNestedAttribute{
+				Attributes: []resource.Attribute{
+					{Name: "name", String: &resource.StringAttribute{ComputedOptionalRequired: schema.Required}},
+				},
+				ComputedOptionalRequired: schema.Computed,
+			},
+		},
+	}
+
+	block, err := protoconvert.ToSchemaV6(attributes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(block.BlockTypes) != 0 {
+		t.Errorf("expected no legacy block types, got %d", len(block.BlockTypes))
+	}
+
+	if len(block.Attributes) != 1 || block.Attributes[0].NestedType == nil {
+		t.Fatalf("expected the nested object to be carried as an attribute with NestedType, got %+v", block.Attributes)
+	}
+}
+
+func TestRoundTrip_listNestedAndPrimitive(t *testing.T) {
+	t.Parallel()
+
+	attributes := []resource.Attribute{
+		{
+			Name: "tags",
+			List: &resource.ListAttribute{
+				ComputedOptionalRequired: schema.Optional,
+				ElementType:              schema.ElementType{String: &schema.StringType{}},
+			},
+		},
+		{
+			Name: "things",
+			ListNested: &resource.ListNestedAttribute{
+				NestedObject: resource.NestedAttributeObject{
+					Attributes: []resource.Attribute{
+						{Name: "id", String: &resource.StringAttribute{ComputedOptionalRequired: schema.Required}},
+					},
+				},
+				ComputedOptionalRequired: schema.Computed,
+			},
+		},
+	}
+
+	block, err := protoconvert.ToSchemaV6(attributes)
+	if err != nil {
+		t.Fatalf("unexpected error converting to proto: %s", err)
+	}
+
+	roundTripped, err := protoconvert.FromSchemaV6(block)
+	if err != nil {
+		t.Fatalf("unexpected error converting back from proto: %s", err)
+	}
+
+	if len(roundTripped) != 2 {
+		t.Fatalf("expected 2 round-tripped attributes, got %d", len(roundTripped))
+	}
+
+	if roundTripped[0].List == nil || roundTripped[0].List.ElementType.String == nil {
+		t.Errorf("expected tags to round-trip as a list of strings, got %+v", roundTripped[0])
+	}
+
+	if roundTripped[1].ListNested == nil || len(roundTripped[1].ListNested.NestedObject.Attributes) != 1 {
+		t.Errorf("expected things to round-trip as a list nested attribute, got %+v", roundTripped[1])
+	}
+}
+
+// TestToSchemaV6_requiredNestedSetsMinItems proves a Required nested
+// attribute carries MinItems: 1 on its NestedType, mirroring the Terraform
+// core convention that a nested attribute's requiredness is enforced through
+// MinItems rather than through Optional/Required alone.
+func TestToSchemaV6_requiredNestedSetsMinItems(t *testing.T) {
+	t.Parallel()
+
+	leaf := []resource.Attribute{
+		{Name: "name", String: &resource.StringAttribute{ComputedOptionalRequired: schema.Required}},
+	}
+
+	attributes := []resource.Attribute{
+		{
+			Name:         "single_attr",
+			SingleNested: &resource.SingleNestedAttribute{Attributes: leaf, ComputedOptionalRequired: schema.Required},
+		},
+	}
+
+	block, err := protoconvert.ToSchemaV6(attributes)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	protoAttribute := block.Attributes[0]
+
+	if protoAttribute.NestedType.MinItems != 1 {
+		t.Errorf("expected MinItems 1 for a required nested attribute, got %d", protoAttribute.NestedType.MinItems)
+	}
+
+	if !protoAttribute.Required || protoAttribute.Optional {
+		t.Errorf("expected Required true and Optional false, got Required=%v Optional=%v", protoAttribute.Required, protoAttribute.Optional)
+	}
+}
+
+// TestToSchemaV6_propagatesNestedAttributeErrors proves a conversion error
+// from a nested attribute is surfaced to the top-level caller rather than
+// silently dropping that attribute from the converted NestedType.
+func TestToSchemaV6_propagatesNestedAttributeErrors(t *testing.T) {
+	t.Parallel()
+
+	attributes := []resource.Attribute{
+		{
+			Name: "single_attr",
+			SingleNested: &resource.SingleNestedAttribute{
+				Attributes:               []resource.Attribute{{Name: "broken"}},
+				ComputedOptionalRequired: schema.Computed,
+			},
+		},
+	}
+
+	if _, err := protoconvert.ToSchemaV6(attributes); err == nil {
+		t.Fatal("expected an error converting a nested attribute with no recognized type set")
+	}
+}
+
+// TestRoundTrip_nonSensitiveStringStaysNil proves a non-sensitive string
+// attribute round-trips with Sensitive: nil rather than Sensitive:
+// pointerTo(false) - a generated schema always leaves Sensitive nil, so a
+// round-tripped one must match or every such attribute shows a spurious
+// diff in CI.
+func TestRoundTrip_nonSensitiveStringStaysNil(t *testing.T) {
+	t.Parallel()
+
+	attributes := []resource.Attribute{
+		{
+			Name:   "name",
+			String: &resource.StringAttribute{ComputedOptionalRequired: schema.Required},
+		},
+	}
+
+	block, err := protoconvert.ToSchemaV6(attributes)
+	if err != nil {
+		t.Fatalf("unexpected error converting to proto: %s", err)
+	}
+
+	roundTripped, err := protoconvert.FromSchemaV6(block)
+	if err != nil {
+		t.Fatalf("unexpected error converting back from proto: %s", err)
+	}
+
+	if roundTripped[0].String == nil {
+		t.Fatalf("expected name to round-trip as a string attribute, got %+v", roundTripped[0])
+	}
+
+	if roundTripped[0].String.Sensitive != nil {
+		t.Errorf("expected Sensitive to stay nil for a non-sensitive attribute, got %v", *roundTripped[0].String.Sensitive)
+	}
+}
+
+func TestRoundTrip_dataSourceSingleNestedAndPrimitive(t *testing.T) {
+	t.Parallel()
+
+	attributes := []datasource.Attribute{
+		{
+			Name: "tags",
+			List: &datasource.ListAttribute{
+				ComputedOptionalRequired: schema.Computed,
+				ElementType:              schema.ElementType{String: &schema.StringType{}},
+			},
+		},
+		{
+			Name: "things",
+			SingleNested: &datasource.SingleNestedAttribute{
+				Attributes: []datasource.Attribute{
+					{Name: "id", String: &datasource.StringAttribute{ComputedOptionalRequired: schema.Computed}},
+				},
+				ComputedOptionalRequired: schema.Computed,
+			},
+		},
+	}
+
+	block, err := protoconvert.ToDataSourceSchemaV6(attributes)
+	if err != nil {
+		t.Fatalf("unexpected error converting to proto: %s", err)
+	}
+
+	roundTripped, err := protoconvert.FromDataSourceSchemaV6(block)
+	if err != nil {
+		t.Fatalf("unexpected error converting back from proto: %s", err)
+	}
+
+	if len(roundTripped) != 2 {
+		t.Fatalf("expected 2 round-tripped attributes, got %d", len(roundTripped))
+	}
+
+	if roundTripped[0].List == nil || roundTripped[0].List.ElementType.String == nil {
+		t.Errorf("expected tags to round-trip as a list of strings, got %+v", roundTripped[0])
+	}
+
+	if roundTripped[1].SingleNested == nil || len(roundTripped[1].SingleNested.Attributes) != 1 {
+		t.Errorf("expected things to round-trip as a single nested attribute, got %+v", roundTripped[1])
+	}
+}
+
+func TestFromSchemaV6_rejectsLegacyBlocks(t *testing.T) {
+	t.Parallel()
+
+	block := &tfprotov6.SchemaBlock{
+		BlockTypes: []*tfprotov6.SchemaNestedBlock{
+			{
+				TypeName: "legacy_block",
+				Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+				Block:    &tfprotov6.SchemaBlock{},
+			},
+		},
+	}
+
+	if _, err := protoconvert.FromSchemaV6(block); err == nil {
+		t.Fatal("expected an error converting a schema with legacy block types")
+	}
+}